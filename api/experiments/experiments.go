@@ -56,6 +56,7 @@ func (a *API) Register(ctx context.Context, name, projectID string, opts Registe
 		Metadata:       opts.Metadata,
 		DatasetID:      opts.DatasetID,
 		DatasetVersion: opts.DatasetVersion,
+		BaseExpID:      opts.BaseExpID,
 	})
 }
 
@@ -69,6 +70,9 @@ func (a *API) List(ctx context.Context, params ListParams) (*ListResponse, error
 	if params.ExperimentName != "" {
 		queryParams["experiment_name"] = params.ExperimentName
 	}
+	if params.ProjectName != "" {
+		queryParams["project_name"] = params.ProjectName
+	}
 	if params.OrgName != "" {
 		queryParams["org_name"] = params.OrgName
 	}
@@ -110,6 +114,33 @@ func (a *API) Get(ctx context.Context, experimentID string) (*Experiment, error)
 	return &result, nil
 }
 
+// Fetch retrieves a single page of events (spans) logged to an experiment with optional cursor pagination.
+func (a *API) Fetch(ctx context.Context, experimentID string, cursor string, limit int) (*FetchResponse, error) {
+	if experimentID == "" {
+		return nil, fmt.Errorf("experiment ID is required")
+	}
+
+	reqBody := map[string]interface{}{
+		"limit": limit,
+	}
+	if cursor != "" {
+		reqBody["cursor"] = cursor
+	}
+
+	resp, err := a.client.POST(ctx, "/v1/experiment/"+experimentID+"/fetch", reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result FetchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // Delete deletes an experiment by its ID.
 func (a *API) Delete(ctx context.Context, experimentID string) error {
 	if experimentID == "" {
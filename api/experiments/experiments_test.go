@@ -2,6 +2,9 @@ package experiments
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -319,6 +322,53 @@ func TestExperiments_Delete_Validation(t *testing.T) {
 	assert.Contains(t, err.Error(), "required")
 }
 
+// TestExperiments_Fetch tests fetching experiment events with pagination
+func TestExperiments_Fetch(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/experiment/exp-123/fetch", r.URL.Path)
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"events": [{"id": "span-1"}, {"id": "span-2"}], "cursor": "next-page"}`))
+	}))
+	defer server.Close()
+
+	api := New(https.NewClient("test-key", server.URL, nil))
+
+	ctx := context.Background()
+	response, err := api.Fetch(ctx, "exp-123", "", 2)
+	require.NoError(t, err)
+	require.Len(t, response.Events, 2)
+	assert.JSONEq(t, `{"id": "span-1"}`, string(response.Events[0]))
+	assert.Equal(t, "next-page", response.Cursor)
+
+	_, err = api.Fetch(ctx, "exp-123", response.Cursor, 2)
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]any{"limit": float64(2)}, bodies[0])
+	assert.Equal(t, map[string]any{"limit": float64(2), "cursor": "next-page"}, bodies[1])
+}
+
+// TestExperiments_Fetch_Validation tests Fetch parameter validation
+func TestExperiments_Fetch_Validation(t *testing.T) {
+	t.Parallel()
+
+	api := New(https.NewClient("test-key", "http://localhost", nil))
+
+	// Test empty experiment ID
+	_, err := api.Fetch(context.Background(), "", "", 10)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "required")
+}
+
 // TestExperiments_EnsureNew tests the EnsureNew parameter
 func TestExperiments_EnsureNew(t *testing.T) {
 	t.Parallel()
@@ -1,7 +1,11 @@
 // Package experiments provides operations for managing Braintrust experiments.
 package experiments
 
-import "github.com/braintrustdata/braintrust-sdk-go/internal/https"
+import (
+	"encoding/json"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/https"
+)
 
 // API provides methods for experiment operations
 type API struct {
@@ -44,6 +48,7 @@ type RegisterOpts struct {
 	Update         bool   // If true, allow reusing existing experiment instead of creating new one
 	DatasetID      string // Optional dataset ID to link to this experiment
 	DatasetVersion string // Optional dataset version
+	BaseExpID      string // Optional ID of the experiment to compare against
 }
 
 // ListParams represents parameters for listing experiments
@@ -52,6 +57,8 @@ type ListParams struct {
 	ProjectID string
 	// ExperimentName filters by specific experiment name
 	ExperimentName string
+	// ProjectName filters experiments by project name
+	ProjectName string
 	// OrgName filters by organization name
 	OrgName string
 	// Limit maximum number of objects to return (default 25, max 1000)
@@ -62,3 +69,9 @@ type ListParams struct {
 type ListResponse struct {
 	Objects []Experiment `json:"objects"`
 }
+
+// FetchResponse represents a paginated response from the fetch endpoint.
+type FetchResponse struct {
+	Events []json.RawMessage `json:"events"`
+	Cursor string            `json:"cursor"`
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/braintrustdata/braintrust-sdk-go/internal/auth"
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
 	bttrace "github.com/braintrustdata/braintrust-sdk-go/trace"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // Client is the main Braintrust SDK client
@@ -122,6 +123,7 @@ func (c *Client) setupTracing() error {
 		SpanFilterFuncs:    convertSpanFilters(c.config.SpanFilterFuncs),
 		EnableConsoleLog:   false,
 		Exporter:           c.config.Exporter,
+		Redactor:           c.config.Redactor,
 		Logger:             c.logger,
 	}
 
@@ -178,6 +180,12 @@ func (c *Client) TracerProvider() *trace.TracerProvider {
 	return c.tracerProvider
 }
 
+// Redactor returns the Redactor set with [WithRedactor], or nil if there is none. The client
+// redacts the spans it exports with it.
+func (c *Client) Redactor() *redact.Redactor {
+	return c.config.Redactor
+}
+
 // Tracer returns an OpenTelemetry Tracer with the given name.
 // This is a convenience method equivalent to calling TracerProvider().Tracer(name, opts...).
 //
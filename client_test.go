@@ -2,16 +2,20 @@ package braintrust
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/braintrustdata/braintrust-sdk-go/internal/auth"
 	intlogger "github.com/braintrustdata/braintrust-sdk-go/internal/logger"
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 func TestNew_WithMinimalConfig(t *testing.T) {
@@ -211,3 +215,56 @@ func TestTracing_WithExporter(t *testing.T) {
 	spans := exporter.GetSpans()
 	assert.GreaterOrEqual(t, len(spans), 1)
 }
+
+func TestNew_WithRedactor(t *testing.T) {
+	t.Parallel()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider()
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	redactor := redact.New(redact.PII()...)
+	client, err := New(tp,
+		WithAPIKey(auth.TestAPIKey),
+		WithProject("test-project"),
+		WithExporter(exporter),
+		WithRedactor(redactor),
+		WithLogger(intlogger.NewFailTestLogger(t)),
+	)
+	require.NoError(t, err)
+	assert.Same(t, redactor, client.Redactor())
+
+	// a span recorded without any redaction of its own is redacted on export
+	_, span := client.Tracer("test-app").Start(context.Background(), "llm-call")
+	span.SetAttributes(
+		attribute.String("braintrust.input_json", `[{"role": "user", "content": "I'm jane@example.com"}]`),
+		attribute.String("braintrust.output_json", `"call (415) 555-0100"`),
+		attribute.String("braintrust.metadata", `{"card": "4111 1111 1111 1111"}`),
+	)
+	span.RecordError(errors.New("rejected jane@example.com"))
+	span.SetStatus(codes.Error, "rejected jane@example.com")
+	span.End()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	attrs := map[attribute.Key]string{}
+	for _, a := range spans[0].Attributes {
+		attrs[a.Key] = a.Value.Emit()
+	}
+	assert.Equal(t, `[{"content":"I'm [EMAIL]","role":"user"}]`, attrs["braintrust.input_json"])
+	assert.Equal(t, `"call [PHONE]"`, attrs["braintrust.output_json"])
+	assert.Equal(t, `{"card":"[CREDIT_CARD]"}`, attrs["braintrust.metadata"])
+	assert.Equal(t, "rejected [EMAIL]", spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1)
+	assert.Contains(t, spans[0].Events[0].Attributes, attribute.String("exception.message", "rejected [EMAIL]"))
+
+	// other clients are not affected
+	other, err := New(tp,
+		WithAPIKey(auth.TestAPIKey),
+		WithProject("test-project"),
+		WithLogger(intlogger.NewFailTestLogger(t)),
+	)
+	require.NoError(t, err)
+	assert.Nil(t, other.Redactor())
+}
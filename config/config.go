@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // Config holds immutable configuration for the Braintrust SDK.
@@ -25,6 +26,7 @@ type Config struct {
 	FilterAISpans   bool
 	SpanFilterFuncs []SpanFilterFunc
 	Exporter        trace.SpanExporter
+	Redactor        *redact.Redactor
 
 	// Logger
 	Logger logger.Logger
@@ -0,0 +1,70 @@
+package eval
+
+import (
+	"fmt"
+	"sync"
+)
+
+const defaultErrorBudgetMinCases = 10
+
+// ErrorBudget stops an eval early when too many cases fail, e.g. because of a bad API key
+// or a broken prompt. The zero value never stops an eval.
+//
+// When the budget is exceeded, in-flight cases are canceled, no more cases are read from the
+// dataset, and [Evaluator.Run] returns a partial [Result] whose [Result.Aborted] is true.
+type ErrorBudget struct {
+	// MaxErrors stops the eval once this many cases have failed. Set it to 1 to fail fast.
+	MaxErrors int
+
+	// MaxErrorRate stops the eval once the fraction of failed cases exceeds this value
+	// (between 0 and 1). It is only checked after MinCases cases have finished (default: 10).
+	MaxErrorRate float64
+	MinCases     int
+}
+
+// enabled returns true if the budget can stop an eval.
+func (b ErrorBudget) enabled() bool {
+	return b.MaxErrors > 0 || b.MaxErrorRate > 0
+}
+
+// budgetTracker counts finished and failed cases against an ErrorBudget.
+type budgetTracker struct {
+	budget   ErrorBudget
+	mu       sync.Mutex
+	finished int
+	failed   int
+	exceeded bool
+}
+
+// record records a finished case and returns an error the first time the budget is exceeded.
+func (t *budgetTracker) record(failed bool) error {
+	if !t.budget.enabled() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finished++
+	if failed {
+		t.failed++
+	}
+	if t.exceeded {
+		return nil
+	}
+
+	minCases := t.budget.MinCases
+	if minCases <= 0 {
+		minCases = defaultErrorBudgetMinCases
+	}
+
+	switch {
+	case t.budget.MaxErrors > 0 && t.failed >= t.budget.MaxErrors:
+		t.exceeded = true
+		return fmt.Errorf("%w: error budget exceeded: %d cases failed (max %d)", errAborted, t.failed, t.budget.MaxErrors)
+	case t.budget.MaxErrorRate > 0 && t.finished >= minCases && float64(t.failed)/float64(t.finished) > t.budget.MaxErrorRate:
+		t.exceeded = true
+		return fmt.Errorf("%w: error budget exceeded: %d of %d cases failed (max rate %.2f)", errAborted, t.failed, t.finished, t.budget.MaxErrorRate)
+	}
+	return nil
+}
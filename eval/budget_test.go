@@ -0,0 +1,133 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetTracker_MaxErrors(t *testing.T) {
+	t.Parallel()
+
+	tracker := &budgetTracker{budget: ErrorBudget{MaxErrors: 2}}
+	assert.NoError(t, tracker.record(true))
+	assert.NoError(t, tracker.record(false))
+
+	err := tracker.record(true)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errAborted)
+	assert.Contains(t, err.Error(), "2 cases failed (max 2)")
+
+	// only reported once
+	assert.NoError(t, tracker.record(true))
+}
+
+func TestBudgetTracker_MaxErrorRate(t *testing.T) {
+	t.Parallel()
+
+	tracker := &budgetTracker{budget: ErrorBudget{MaxErrorRate: 0.5, MinCases: 4}}
+
+	// 100% failure rate, but not enough cases yet
+	assert.NoError(t, tracker.record(true))
+	assert.NoError(t, tracker.record(true))
+	assert.NoError(t, tracker.record(false))
+
+	err := tracker.record(true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 of 4 cases failed (max rate 0.50)")
+}
+
+func TestBudgetTracker_Disabled(t *testing.T) {
+	t.Parallel()
+
+	tracker := &budgetTracker{}
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, tracker.record(true))
+	}
+}
+
+func TestEval_Run_FailFast(t *testing.T) {
+	t.Parallel()
+
+	var dataset []Case[testInput, testOutput]
+	for i := 0; i < 1000; i++ {
+		dataset = append(dataset, Case[testInput, testOutput]{Input: testInput{Value: "bad-key"}})
+	}
+
+	var calls atomic.Int32
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		calls.Add(1)
+		return testOutput{}, errors.New("invalid API key")
+	})
+
+	ute := newUnitTestEval(t, NewDataset(dataset), task, nil, 4)
+	ute.eval.errorBudget = ErrorBudget{MaxErrors: 1}
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errAborted)
+	assert.True(t, result.Aborted())
+	assert.Contains(t, result.String(), "Aborted: eval aborted: error budget exceeded")
+
+	// only the cases already in flight ran
+	assert.Less(t, int(calls.Load()), 1000)
+	assert.Less(t, len(result.Cases()), 1000)
+}
+
+func TestEval_Run_ErrorBudgetCancelsInFlightCases(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "fail"}},
+		{Input: testInput{Value: "hang"}},
+	})
+
+	started := make(chan struct{})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "hang" {
+			close(started)
+			<-ctx.Done()
+			return testOutput{}, ctx.Err()
+		}
+		<-started
+		return testOutput{}, errors.New("task failed")
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 2)
+	ute.eval.errorBudget = ErrorBudget{MaxErrors: 1}
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.True(t, result.Aborted())
+
+	rows := result.Cases()
+	require.Len(t, rows, 2)
+	assert.ErrorIs(t, rows[1].Error, errAborted)
+}
+
+func TestEval_Run_NotAborted(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "b"}},
+	})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "a" {
+			return testOutput{}, errors.New("task failed")
+		}
+		return testOutput{}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.errorBudget = ErrorBudget{MaxErrors: 2}
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.False(t, result.Aborted())
+	assert.Len(t, result.Cases(), 2)
+}
@@ -0,0 +1,324 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/braintrustdata/braintrust-sdk-go/api/experiments"
+)
+
+// Comparison describes how an eval's scores changed compared to a base experiment.
+// Cases are matched to the base experiment by dataset record ID, or by [Opts.CaseID]
+// for in-memory datasets.
+type Comparison struct {
+	BaseExperimentID   string
+	BaseExperimentName string
+
+	// Scores contains the change in the mean of each score, sorted by name.
+	// Only scores recorded by both experiments are included.
+	Scores []ScoreDelta
+
+	// Improved and Regressed contain the scores of individual cases that went up or down
+	// compared to the matching case in the base experiment. A case with several scores
+	// can appear in both.
+	Improved  []CaseDelta
+	Regressed []CaseDelta
+
+	// Skipped counts the cases that couldn't be compared, because their input couldn't be
+	// encoded to match them to the base experiment.
+	Skipped int
+}
+
+// ScoreDelta is the change in a single score compared to a base experiment.
+type ScoreDelta struct {
+	Name     string
+	Mean     float64 // Mean of the score in this eval
+	BaseMean float64 // Mean of the score in the base experiment
+	Delta    float64 // Mean - BaseMean
+
+	// Improvements and Regressions count the matched cases where the score went up or down.
+	Improvements int
+	Regressions  int
+}
+
+// CaseDelta is the change in one score of a single case compared to the matching base case.
+type CaseDelta struct {
+	Case      CaseResult // The case in this eval
+	Name      string     // The score name
+	Score     float64    // The score in this eval
+	BaseScore float64    // The score of the matching case in the base experiment
+	Delta     float64    // Score - BaseScore
+}
+
+// HasRegressions returns true if any case scored lower than in the base experiment.
+func (c *Comparison) HasRegressions() bool {
+	return len(c.Regressed) > 0
+}
+
+// loggedCase is a case that was logged to an experiment, reassembled from its spans.
+type loggedCase struct {
+	key    string
+	scores map[string]float64
+	failed bool // true if any span in the case recorded an error
+}
+
+// experimentRow is the subset of a fetched experiment span needed to reassemble cases.
+type experimentRow struct {
+	RootSpanID  string              `json:"root_span_id"`
+	SpanParents []string            `json:"span_parents"`
+	Input       json.RawMessage     `json:"input"`
+	Scores      map[string]*float64 `json:"scores"`
+	Error       json.RawMessage     `json:"error"`
+	Origin      *struct {
+		ID string `json:"id"`
+	} `json:"origin"`
+	Metadata struct {
+		CaseID string `json:"case_id"`
+	} `json:"metadata"`
+}
+
+// fetchLoggedCases fetches all spans logged to an experiment and groups them into cases
+// by root span.
+func fetchLoggedCases(ctx context.Context, experimentsAPI *experiments.API, experimentID string) ([]loggedCase, error) {
+	type partial struct {
+		loggedCase
+		input    json.RawMessage
+		originID string
+		caseID   string
+		hasRoot  bool // the root span, which identifies the case, was logged
+	}
+
+	var order []string
+	byRoot := make(map[string]*partial)
+
+	cursor := ""
+	for {
+		page, err := experimentsAPI.Fetch(ctx, experimentID, cursor, 1000)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch experiment events: %w", err)
+		}
+
+		for _, raw := range page.Events {
+			var row experimentRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal experiment event: %w", err)
+			}
+
+			p, ok := byRoot[row.RootSpanID]
+			if !ok {
+				p = &partial{loggedCase: loggedCase{scores: map[string]float64{}}}
+				byRoot[row.RootSpanID] = p
+				order = append(order, row.RootSpanID)
+			}
+
+			if len(row.SpanParents) == 0 {
+				p.hasRoot = true
+				p.input = row.Input
+				if row.Origin != nil {
+					p.originID = row.Origin.ID
+				}
+				p.caseID = row.Metadata.CaseID
+			}
+			for name, score := range row.Scores {
+				if score != nil {
+					p.scores[name] = *score
+				}
+			}
+			if len(row.Error) > 0 && !bytes.Equal(row.Error, []byte("null")) {
+				p.failed = true
+			}
+		}
+
+		cursor = page.Cursor
+		if cursor == "" || len(page.Events) == 0 {
+			break
+		}
+	}
+
+	cases := make([]loggedCase, 0, len(order))
+	for _, root := range order {
+		p := byRoot[root]
+		if !p.hasRoot {
+			// without its root span, a partially logged case can't be matched
+			continue
+		}
+		if p.originID == "" && p.caseID != "" {
+			p.key = matchKey("", p.caseID)
+		} else {
+			// cases logged without a case ID are matched by their input hash
+			key, err := caseKey(p.originID, p.input)
+			if err != nil {
+				return nil, err
+			}
+			p.key = key
+		}
+		cases = append(cases, p.loggedCase)
+	}
+	return cases, nil
+}
+
+// compareCases compares the cases of an eval to the cases logged to a base experiment.
+// Cases whose input can't be encoded are counted in [Comparison.Skipped].
+func compareCases(base *experiments.Experiment, cases []CaseResult, baseCases []loggedCase) *Comparison {
+	// Average the base scores of each case, in case it was logged more than once.
+	type scoreSum struct {
+		sum   float64
+		count int
+	}
+	baseByKey := make(map[string]map[string]*scoreSum)
+	baseResults := make([]CaseResult, 0, len(baseCases))
+	for _, bc := range baseCases {
+		baseResults = append(baseResults, CaseResult{Scores: bc.scores})
+		sums, ok := baseByKey[bc.key]
+		if !ok {
+			sums = make(map[string]*scoreSum)
+			baseByKey[bc.key] = sums
+		}
+		for name, val := range bc.scores {
+			if sums[name] == nil {
+				sums[name] = &scoreSum{}
+			}
+			sums[name].sum += val
+			sums[name].count++
+		}
+	}
+
+	deltas := make(map[string]*ScoreDelta)
+	baseSummaries := summarizeScores(baseResults)
+	for _, s := range summarizeScores(cases) {
+		for _, b := range baseSummaries {
+			if s.Name == b.Name && s.Count > 0 && b.Count > 0 {
+				deltas[s.Name] = &ScoreDelta{
+					Name:     s.Name,
+					Mean:     s.Mean,
+					BaseMean: b.Mean,
+					Delta:    s.Mean - b.Mean,
+				}
+			}
+		}
+	}
+
+	comparison := &Comparison{
+		BaseExperimentID:   base.ID,
+		BaseExperimentName: base.Name,
+	}
+
+	for _, c := range cases {
+		key := c.key
+		if key == "" {
+			var err error
+			if key, err = caseKey(c.ID, c.Input); err != nil {
+				comparison.Skipped++
+				continue
+			}
+		}
+		sums, ok := baseByKey[key]
+		if !ok {
+			continue
+		}
+
+		// iterate in name order so the results are deterministic
+		names := make([]string, 0, len(c.Scores))
+		for name := range c.Scores {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			sum, ok := sums[name]
+			if !ok {
+				continue
+			}
+			d := CaseDelta{
+				Case:      c,
+				Name:      name,
+				Score:     c.Scores[name],
+				BaseScore: sum.sum / float64(sum.count),
+			}
+			d.Delta = d.Score - d.BaseScore
+			switch {
+			case d.Delta > 0:
+				comparison.Improved = append(comparison.Improved, d)
+				if deltas[name] != nil {
+					deltas[name].Improvements++
+				}
+			case d.Delta < 0:
+				comparison.Regressed = append(comparison.Regressed, d)
+				if deltas[name] != nil {
+					deltas[name].Regressions++
+				}
+			}
+		}
+	}
+
+	for _, d := range deltas {
+		comparison.Scores = append(comparison.Scores, *d)
+	}
+	sort.Slice(comparison.Scores, func(i, j int) bool {
+		return comparison.Scores[i].Name < comparison.Scores[j].Name
+	})
+
+	return comparison
+}
+
+// caseKey returns the key used to match a case across experiments. Cases from a
+// Braintrust dataset are matched by record ID and other cases by a hash of their input.
+func caseKey(id string, input any) (string, error) {
+	if id != "" {
+		return matchKey(id, ""), nil
+	}
+	hash, err := inputHash(input)
+	if err != nil {
+		return "", err
+	}
+	return matchKey("", hash), nil
+}
+
+// matchKey returns the key used to match a case with dataset record ID id, or with case ID
+// caseID if it isn't from a dataset. See [Opts.CaseID]. Record IDs and case IDs share a key,
+// because [DatasetAPI.Upload] uses case IDs as record IDs, so an uploaded case matches the
+// case it was uploaded from. It returns an empty string if both are empty.
+func matchKey(id, caseID string) string {
+	switch {
+	case id != "":
+		return "id:" + id
+	case caseID != "":
+		return "id:" + caseID
+	default:
+		return ""
+	}
+}
+
+// inputHash returns a hex-encoded SHA-256 hash of the canonical JSON encoding of input.
+// Object keys are sorted so the hash doesn't depend on struct field or map order.
+func inputHash(input any) (string, error) {
+	b, err := canonicalJSON(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode input: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON encodes v as JSON with sorted object keys and no insignificant whitespace.
+func canonicalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// round trip through a generic value so objects become maps, which encoding/json
+	// always writes with sorted keys. UseNumber keeps numbers exactly as encoded.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
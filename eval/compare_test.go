@@ -0,0 +1,349 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/api"
+	"github.com/braintrustdata/braintrust-sdk-go/api/experiments"
+	"github.com/braintrustdata/braintrust-sdk-go/internal/https"
+	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+)
+
+func TestInputHash_KeyOrderInsensitive(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		B string `json:"b"`
+		A int    `json:"a"`
+	}
+
+	fromStruct, err := inputHash(input{B: "x", A: 1})
+	require.NoError(t, err)
+
+	fromMap, err := inputHash(map[string]any{"a": 1, "b": "x"})
+	require.NoError(t, err)
+
+	fromRaw, err := inputHash(json.RawMessage(`{ "b": "x", "a": 1 }`))
+	require.NoError(t, err)
+
+	assert.Equal(t, fromStruct, fromMap)
+	assert.Equal(t, fromStruct, fromRaw)
+
+	other, err := inputHash(input{B: "y", A: 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, fromStruct, other)
+}
+
+func TestCaseKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := caseKey("row-1", "ignored")
+	require.NoError(t, err)
+	assert.Equal(t, "id:row-1", key)
+
+	key, err = caseKey("", "hello")
+	require.NoError(t, err)
+	hash, err := inputHash("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "id:"+hash, key)
+}
+
+func TestCompareCases(t *testing.T) {
+	t.Parallel()
+
+	keyA, _ := caseKey("", "a")
+	keyB, _ := caseKey("", "b")
+
+	base := &experiments.Experiment{ID: "exp-base", Name: "base"}
+	baseCases := []loggedCase{
+		{key: keyA, scores: map[string]float64{"accuracy": 0.5, "fluency": 1}},
+		{key: keyB, scores: map[string]float64{"accuracy": 1}},
+		{key: "id:row-c", scores: map[string]float64{"accuracy": 0}},
+		{key: "id:unmatched", scores: map[string]float64{"accuracy": 0.5}},
+	}
+	cases := []CaseResult{
+		{Input: "a", Scores: map[string]float64{"accuracy": 1, "fluency": 1}},
+		{Input: "b", Scores: map[string]float64{"accuracy": 0}},
+		{Input: "c", ID: "row-c", Scores: map[string]float64{"accuracy": 0, "new_score": 1}},
+		{Input: "d", Scores: map[string]float64{"accuracy": 1}},
+	}
+
+	comparison := compareCases(base, cases, baseCases)
+
+	assert.Equal(t, "exp-base", comparison.BaseExperimentID)
+	assert.Equal(t, "base", comparison.BaseExperimentName)
+
+	require.Len(t, comparison.Scores, 2)
+	accuracy := comparison.Scores[0]
+	assert.Equal(t, "accuracy", accuracy.Name)
+	assert.InDelta(t, 0.5, accuracy.Mean, 1e-9)
+	assert.InDelta(t, 0.5, accuracy.BaseMean, 1e-9)
+	assert.InDelta(t, 0.0, accuracy.Delta, 1e-9)
+	assert.Equal(t, 1, accuracy.Improvements)
+	assert.Equal(t, 1, accuracy.Regressions)
+
+	fluency := comparison.Scores[1]
+	assert.Equal(t, "fluency", fluency.Name)
+	assert.Equal(t, 0, fluency.Improvements)
+	assert.Equal(t, 0, fluency.Regressions)
+
+	require.Len(t, comparison.Improved, 1)
+	assert.Equal(t, "a", comparison.Improved[0].Case.Input)
+	assert.Equal(t, "accuracy", comparison.Improved[0].Name)
+	assert.Equal(t, 0.5, comparison.Improved[0].Delta)
+
+	require.Len(t, comparison.Regressed, 1)
+	assert.Equal(t, "b", comparison.Regressed[0].Case.Input)
+	assert.Equal(t, 1.0, comparison.Regressed[0].BaseScore)
+	assert.Equal(t, 0.0, comparison.Regressed[0].Score)
+	assert.True(t, comparison.HasRegressions())
+	assert.Equal(t, 0, comparison.Skipped)
+}
+
+func TestCompareCases_SkipsUnencodableInput(t *testing.T) {
+	t.Parallel()
+
+	keyA, _ := caseKey("", "a")
+	base := &experiments.Experiment{ID: "exp-base", Name: "base"}
+	baseCases := []loggedCase{{key: keyA, scores: map[string]float64{"accuracy": 0.5}}}
+	cases := []CaseResult{
+		{Input: func() {}, Scores: map[string]float64{"accuracy": 0}},
+		{Input: "a", Scores: map[string]float64{"accuracy": 1}},
+	}
+
+	comparison := compareCases(base, cases, baseCases)
+
+	assert.Equal(t, 1, comparison.Skipped)
+	require.Len(t, comparison.Improved, 1)
+	assert.Equal(t, "a", comparison.Improved[0].Case.Input)
+	assert.Empty(t, comparison.Regressed)
+}
+
+// newFetchServer returns a server that serves the given events from the experiment fetch
+// endpoint, two events per page.
+func newFetchServer(t *testing.T, experimentID string, events []map[string]any) *api.API {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/experiment/"+experimentID+"/fetch", r.URL.Path)
+
+		var body struct {
+			Cursor string `json:"cursor"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		start := 0
+		if body.Cursor != "" {
+			_ = json.Unmarshal([]byte(body.Cursor), &start)
+		}
+		end := minInt(start+2, len(events))
+		cursor := ""
+		if end < len(events) {
+			cursor = string(mustJSON(t, end))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustJSON(t, map[string]any{"events": events[start:end], "cursor": cursor}))
+	}))
+	t.Cleanup(server.Close)
+
+	return api.NewWithHTTPSClient(https.NewClient("test-key", server.URL, nil))
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func TestFetchLoggedCases(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newFetchServer(t, "exp-base", []map[string]any{
+		{"root_span_id": "r1", "span_parents": nil, "input": map[string]any{"value": "a"}},
+		{"root_span_id": "r1", "span_parents": []string{"r1"}, "scores": map[string]any{"accuracy": 1}},
+		{"root_span_id": "r2", "span_parents": nil, "input": map[string]any{"value": "b"}, "origin": map[string]any{"id": "row-b"}},
+		{"root_span_id": "r2", "span_parents": []string{"r2"}, "scores": map[string]any{"accuracy": nil, "fluency": 0.5}},
+		{"root_span_id": "r3", "span_parents": nil, "input": map[string]any{"value": "c"}, "error": "task failed"},
+		// a case whose root span wasn't logged has no key
+		{"root_span_id": "r4", "span_parents": []string{"r4"}, "scores": map[string]any{"accuracy": 1}},
+	})
+
+	cases, err := fetchLoggedCases(context.Background(), apiClient.Experiments(), "exp-base")
+	require.NoError(t, err)
+	require.Len(t, cases, 3)
+
+	keyA, _ := caseKey("", testInput{Value: "a"})
+	assert.Equal(t, keyA, cases[0].key)
+	assert.Equal(t, map[string]float64{"accuracy": 1}, cases[0].scores)
+	assert.False(t, cases[0].failed)
+
+	assert.Equal(t, "id:row-b", cases[1].key)
+	assert.Equal(t, map[string]float64{"fluency": 0.5}, cases[1].scores)
+
+	assert.True(t, cases[2].failed)
+}
+
+func TestEval_Run_BaseExperimentComparison(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}, Expected: testOutput{Result: "a"}},
+		{Input: testInput{Value: "b"}, Expected: testOutput{Result: "b"}},
+	})
+
+	// the task only gets "a" right, the base experiment only got "b" right
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "a" {
+			return testOutput{Result: "a"}, nil
+		}
+		return testOutput{Result: "wrong"}, nil
+	})
+	scorer := NewScorer("exact", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+		if r.Output == r.Expected {
+			return S(1), nil
+		}
+		return S(0), nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{scorer}, 2)
+	ute.eval.apiClient = newFetchServer(t, "exp-base", []map[string]any{
+		{"root_span_id": "r1", "input": map[string]any{"value": "a"}},
+		{"root_span_id": "r1", "span_parents": []string{"r1"}, "scores": map[string]any{"exact": 0}},
+		{"root_span_id": "r2", "input": map[string]any{"value": "b"}},
+		{"root_span_id": "r2", "span_parents": []string{"r2"}, "scores": map[string]any{"exact": 1}},
+	})
+	ute.eval.baseExperiment = &experiments.Experiment{ID: "exp-base", Name: "base-experiment"}
+
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+
+	comparison := result.Comparison()
+	require.NotNil(t, comparison)
+	require.Len(t, comparison.Scores, 1)
+	assert.Equal(t, 0.0, comparison.Scores[0].Delta)
+	require.Len(t, comparison.Improved, 1)
+	assert.Equal(t, testInput{Value: "a"}, comparison.Improved[0].Case.Input)
+	require.Len(t, comparison.Regressed, 1)
+	assert.Equal(t, testInput{Value: "b"}, comparison.Regressed[0].Case.Input)
+	assert.True(t, comparison.HasRegressions())
+
+	assert.Contains(t, result.String(), "Compared to base-experiment:")
+	assert.Contains(t, result.String(), "exact: +0.00% (1 improvements, 1 regressions)")
+}
+
+func TestEval_Run_NoBaseExperiment(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, result.Comparison())
+}
+
+func TestResolveBaseExperiment(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/experiment":
+			assert.Equal(t, "my-project", r.URL.Query().Get("project_name"))
+			if r.URL.Query().Get("experiment_name") == "main" {
+				_, _ = w.Write([]byte(`{"objects": [{"id": "exp-main", "name": "main"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"objects": []}`))
+		case "/v1/experiment/exp-by-id":
+			_, _ = w.Write([]byte(`{"id": "exp-by-id", "name": "by-id"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	apiClient := api.NewWithHTTPSClient(https.NewClient("test-key", server.URL, nil))
+	ctx := context.Background()
+
+	exp, err := resolveBaseExperiment(ctx, apiClient, "my-project", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, exp)
+
+	exp, err = resolveBaseExperiment(ctx, apiClient, "my-project", "main", "")
+	require.NoError(t, err)
+	assert.Equal(t, "exp-main", exp.ID)
+
+	exp, err = resolveBaseExperiment(ctx, apiClient, "my-project", "main", "exp-by-id")
+	require.NoError(t, err)
+	assert.Equal(t, "by-id", exp.Name)
+
+	_, err = resolveBaseExperiment(ctx, apiClient, "my-project", "missing", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestEval_CaseID(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "b"}, ID: "row-b", XactID: "x1"},
+	})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+	scorer := NewScorer("exact", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+		return S(1), nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{scorer}, 1)
+	ute.eval.caseID = func(input testInput) string { return "case-" + input.Value }
+	// the base experiment logged "a" with a different input but the same case ID
+	ute.eval.apiClient = newFetchServer(t, "exp-base", []map[string]any{
+		{"root_span_id": "r1", "input": map[string]any{"value": "A"}, "metadata": map[string]any{"case_id": "case-a"}},
+		{"root_span_id": "r1", "span_parents": []string{"r1"}, "scores": map[string]any{"exact": 0}},
+	})
+	ute.eval.baseExperiment = &experiments.Experiment{ID: "exp-base", Name: "base-experiment"}
+
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Comparison().Improved, 1)
+	assert.Equal(t, testInput{Value: "a"}, result.Comparison().Improved[0].Case.Input)
+
+	var evalSpans []oteltest.Span
+	for _, span := range ute.exporter.Flush() {
+		if span.Name() == "eval" {
+			evalSpans = append(evalSpans, span)
+		}
+	}
+	require.Len(t, evalSpans, 2)
+	evalSpans[0].AssertJSONAttrEquals("braintrust.metadata", map[string]any{"case_id": "case-a"})
+	assert.False(t, evalSpans[1].HasAttr("braintrust.metadata"), "dataset cases are identified by record ID")
+}
+
+func TestFetchLoggedCases_CaseID(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newFetchServer(t, "exp-base", []map[string]any{
+		{"root_span_id": "r1", "input": map[string]any{"value": "a"}, "metadata": map[string]any{"case_id": "custom"}},
+		{"root_span_id": "r2", "input": map[string]any{"value": "b"}, "metadata": map[string]any{"case_id": "custom"}, "origin": map[string]any{"id": "row-b"}},
+	})
+
+	cases, err := fetchLoggedCases(context.Background(), apiClient.Experiments(), "exp-base")
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+	assert.Equal(t, matchKey("", "custom"), cases[0].key)
+	assert.Equal(t, "id:row-b", cases[1].key, "the record ID takes precedence")
+}
@@ -28,6 +28,11 @@ func (s *sliceCases[I, R]) Next() (Case[I, R], error) {
 	return c, nil
 }
 
+// Len returns the number of cases in the slice.
+func (s *sliceCases[I, R]) Len() int {
+	return len(s.cases)
+}
+
 // ID returns empty string for literal in-memory cases.
 func (s *sliceCases[I, R]) ID() string {
 	return ""
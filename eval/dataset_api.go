@@ -13,7 +13,8 @@ import (
 // DatasetAPI provides methods for loading datasets with automatic type conversion
 // so they can be easily used in evals.
 type DatasetAPI[I, R any] struct {
-	api *api.API
+	api         *api.API
+	projectName string
 }
 
 // DatasetQueryOpts contains options for querying datasets.
@@ -0,0 +1,311 @@
+package eval
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxJSONLLineSize is the longest line a JSONL dataset can contain.
+const maxJSONLLineSize = 16 * 1024 * 1024
+
+// NewJSONLDataset creates a Dataset that streams cases from JSON Lines read from r.
+// Each non-empty line is an object with an "input" and optional "expected", "metadata"
+// and "tags" fields:
+//
+//	{"input": {"question": "2+2?"}, "expected": "4", "tags": ["math"]}
+//
+// Inputs and expected values are converted to I and R through JSON, so a string
+// containing JSON can also be used for a struct type. A line that can't be parsed
+// produces an error for that case only.
+func NewJSONLDataset[I, R any](r io.Reader) Dataset[I, R] {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxJSONLLineSize)
+	return &jsonlCases[I, R]{scanner: scanner}
+}
+
+// OpenJSONLDataset opens the JSON Lines file at path and returns a Dataset that streams
+// cases from it. See [NewJSONLDataset] for the format. The file is closed once all cases
+// have been read.
+func OpenJSONLDataset[I, R any](path string) (Dataset[I, R], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	ds := NewJSONLDataset[I, R](f).(*jsonlCases[I, R])
+	ds.closer = f
+	return ds, nil
+}
+
+// jsonlCases implements the Dataset interface for JSON Lines.
+type jsonlCases[I, R any] struct {
+	scanner *bufio.Scanner
+	closer  io.Closer // closed once the reader is exhausted, may be nil
+	line    int
+	done    bool
+}
+
+// jsonlRecord is a single line of a JSONL dataset.
+type jsonlRecord struct {
+	Input    any      `json:"input"`
+	Expected any      `json:"expected"`
+	Metadata Metadata `json:"metadata"`
+	Tags     []string `json:"tags"`
+}
+
+// Next returns the next case, or io.EOF if there are no more cases.
+func (j *jsonlCases[I, R]) Next() (Case[I, R], error) {
+	var zero Case[I, R]
+	for !j.done {
+		if !j.scanner.Scan() {
+			j.finish()
+			if err := j.scanner.Err(); err != nil {
+				return zero, fmt.Errorf("failed to read line %d: %w", j.line+1, err)
+			}
+			break
+		}
+		j.line++
+
+		line := strings.TrimSpace(j.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var rec jsonlRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return zero, fmt.Errorf("failed to parse line %d: %w", j.line, err)
+		}
+		c, err := newFileCase[I, R](rec.Input, rec.Expected, rec.Metadata, rec.Tags)
+		if err != nil {
+			return zero, fmt.Errorf("line %d: %w", j.line, err)
+		}
+		return c, nil
+	}
+	return zero, io.EOF
+}
+
+func (j *jsonlCases[I, R]) finish() {
+	j.done = true
+	if j.closer != nil {
+		_ = j.closer.Close()
+	}
+}
+
+// ID returns empty string for local datasets.
+func (j *jsonlCases[I, R]) ID() string {
+	return ""
+}
+
+// Version returns empty string for local datasets.
+func (j *jsonlCases[I, R]) Version() string {
+	return ""
+}
+
+// CSVColumns maps the columns of a CSV dataset to the fields of a [Case].
+// Columns are referenced by their name in the header row.
+type CSVColumns struct {
+	// Input is the column holding the input. If empty, the input is an object built from
+	// every column that isn't mapped to another field, with column names as keys and
+	// cells as string values.
+	Input string
+
+	// Expected is the column holding the expected output. Optional.
+	Expected string
+
+	// Tags is a column holding tags separated by TagSeparator (default ","). Optional.
+	Tags         string
+	TagSeparator string
+
+	// Metadata lists columns that are copied into the case metadata. Optional.
+	Metadata []string
+}
+
+// NewCSVDataset creates a Dataset that streams cases from CSV read from r. The first row
+// must be a header naming the columns, which are mapped to case fields by columns.
+//
+// Cell values are converted to I and R through JSON, so a cell can contain a JSON
+// object for a struct type, or a number for a numeric type. String types use the
+// cell as-is.
+func NewCSVDataset[I, R any](r io.Reader, columns CSVColumns) Dataset[I, R] {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // validated per row to give better errors
+	return &csvCases[I, R]{reader: reader, columns: columns}
+}
+
+// OpenCSVDataset opens the CSV file at path and returns a Dataset that streams cases from it.
+// See [NewCSVDataset] for the format. The file is closed once all cases have been read.
+func OpenCSVDataset[I, R any](path string, columns CSVColumns) (Dataset[I, R], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	ds := NewCSVDataset[I, R](f, columns).(*csvCases[I, R])
+	ds.closer = f
+	return ds, nil
+}
+
+// csvCases implements the Dataset interface for CSV.
+type csvCases[I, R any] struct {
+	reader  *csv.Reader
+	columns CSVColumns
+	closer  io.Closer // closed once the reader is exhausted, may be nil
+	header  []string
+	done    bool
+}
+
+// Next returns the next case, or io.EOF if there are no more cases.
+func (c *csvCases[I, R]) Next() (Case[I, R], error) {
+	var zero Case[I, R]
+	if c.done {
+		return zero, io.EOF
+	}
+
+	if c.header == nil {
+		header, err := c.reader.Read()
+		if err != nil {
+			c.finish()
+			if err == io.EOF {
+				return zero, io.EOF
+			}
+			return zero, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		if err := c.validateHeader(header); err != nil {
+			c.finish()
+			return zero, err
+		}
+		c.header = header
+	}
+
+	row, err := c.reader.Read()
+	if err == io.EOF {
+		c.finish()
+		return zero, io.EOF
+	}
+	if err != nil {
+		// the csv package can keep reading after a malformed row
+		return zero, fmt.Errorf("failed to read CSV row: %w", err)
+	}
+	line, _ := c.reader.FieldPos(0)
+	if len(row) != len(c.header) {
+		return zero, fmt.Errorf("line %d: expected %d columns, got %d", line, len(c.header), len(row))
+	}
+
+	cells := make(map[string]string, len(row))
+	for i, name := range c.header {
+		cells[name] = row[i]
+	}
+
+	var input any
+	if c.columns.Input != "" {
+		input = cells[c.columns.Input]
+	} else {
+		inputObj := make(map[string]any)
+		for name, value := range cells {
+			if !c.isMapped(name) {
+				inputObj[name] = value
+			}
+		}
+		input = inputObj
+	}
+
+	var expected any
+	if c.columns.Expected != "" && cells[c.columns.Expected] != "" {
+		expected = cells[c.columns.Expected]
+	}
+
+	var tags []string
+	if c.columns.Tags != "" && cells[c.columns.Tags] != "" {
+		sep := c.columns.TagSeparator
+		if sep == "" {
+			sep = ","
+		}
+		for _, tag := range strings.Split(cells[c.columns.Tags], sep) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	var metadata Metadata
+	if len(c.columns.Metadata) > 0 {
+		metadata = make(Metadata, len(c.columns.Metadata))
+		for _, name := range c.columns.Metadata {
+			metadata[name] = cells[name]
+		}
+	}
+
+	cs, err := newFileCase[I, R](input, expected, metadata, tags)
+	if err != nil {
+		return zero, fmt.Errorf("line %d: %w", line, err)
+	}
+	return cs, nil
+}
+
+// validateHeader checks that every mapped column exists in the header.
+func (c *csvCases[I, R]) validateHeader(header []string) error {
+	present := make(map[string]bool, len(header))
+	for _, name := range header {
+		present[name] = true
+	}
+
+	mapped := append([]string{c.columns.Input, c.columns.Expected, c.columns.Tags}, c.columns.Metadata...)
+	for _, name := range mapped {
+		if name != "" && !present[name] {
+			return fmt.Errorf("CSV column %q not found in header", name)
+		}
+	}
+	return nil
+}
+
+// isMapped returns true if the column is mapped to a field other than the input.
+func (c *csvCases[I, R]) isMapped(name string) bool {
+	if name == c.columns.Expected || name == c.columns.Tags {
+		return true
+	}
+	for _, m := range c.columns.Metadata {
+		if name == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *csvCases[I, R]) finish() {
+	c.done = true
+	if c.closer != nil {
+		_ = c.closer.Close()
+	}
+}
+
+// ID returns empty string for local datasets.
+func (c *csvCases[I, R]) ID() string {
+	return ""
+}
+
+// Version returns empty string for local datasets.
+func (c *csvCases[I, R]) Version() string {
+	return ""
+}
+
+// newFileCase builds a Case from values decoded from a file, converting the input
+// and expected values to I and R.
+func newFileCase[I, R any](input, expected any, metadata Metadata, tags []string) (Case[I, R], error) {
+	typedInput, err := convertToType[I](input)
+	if err != nil {
+		return Case[I, R]{}, fmt.Errorf("invalid input: %w", err)
+	}
+	typedExpected, err := convertToType[R](expected)
+	if err != nil {
+		return Case[I, R]{}, fmt.Errorf("invalid expected: %w", err)
+	}
+	return Case[I, R]{
+		Input:    typedInput,
+		Expected: typedExpected,
+		Metadata: metadata,
+		Tags:     tags,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package eval
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll reads every case from a dataset, collecting iterator errors separately.
+func readAll[I, R any](t *testing.T, ds Dataset[I, R]) ([]Case[I, R], []error) {
+	t.Helper()
+	var cases []Case[I, R]
+	var errs []error
+	for i := 0; i < 1000; i++ {
+		c, err := ds.Next()
+		if err == io.EOF {
+			return cases, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		cases = append(cases, c)
+	}
+	t.Fatal("dataset never returned io.EOF")
+	return nil, nil
+}
+
+func TestJSONLDataset(t *testing.T) {
+	t.Parallel()
+
+	input := `{"input": {"question": "2+2?"}, "expected": {"answer": "4"}, "tags": ["math"], "metadata": {"difficulty": "easy"}}
+
+{"input": "{\"question\": \"capital of France?\"}", "expected": {"answer": "Paris"}}
+not json
+{"input": {"question": "empty expected"}}
+`
+	ds := NewJSONLDataset[testDatasetInput, testDatasetOutput](strings.NewReader(input))
+	assert.Empty(t, ds.ID())
+	assert.Empty(t, ds.Version())
+
+	cases, errs := readAll(t, ds)
+	require.Len(t, cases, 3)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "failed to parse line 4")
+
+	assert.Equal(t, testDatasetInput{Question: "2+2?"}, cases[0].Input)
+	assert.Equal(t, testDatasetOutput{Answer: "4"}, cases[0].Expected)
+	assert.Equal(t, []string{"math"}, cases[0].Tags)
+	assert.Equal(t, map[string]interface{}{"difficulty": "easy"}, cases[0].Metadata)
+
+	// JSON strings are parsed into struct types
+	assert.Equal(t, testDatasetInput{Question: "capital of France?"}, cases[1].Input)
+	assert.Equal(t, testDatasetOutput{Answer: "Paris"}, cases[1].Expected)
+
+	assert.Equal(t, testDatasetOutput{}, cases[2].Expected)
+}
+
+func TestJSONLDataset_ConversionError(t *testing.T) {
+	t.Parallel()
+
+	ds := NewJSONLDataset[int, int](strings.NewReader(`{"input": 1, "expected": 2}
+{"input": "not a number", "expected": 3}
+`))
+	cases, errs := readAll(t, ds)
+	require.Len(t, cases, 1)
+	assert.Equal(t, 1, cases[0].Input)
+	assert.Equal(t, 2, cases[0].Expected)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "line 2: invalid input")
+}
+
+func TestOpenJSONLDataset(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "golden.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"input": "a", "expected": "A"}
+{"input": "b", "expected": "B"}
+`), 0o600))
+
+	ds, err := OpenJSONLDataset[string, string](path)
+	require.NoError(t, err)
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	require.Len(t, cases, 2)
+	assert.Equal(t, "b", cases[1].Input)
+	assert.Equal(t, "B", cases[1].Expected)
+
+	_, err = OpenJSONLDataset[string, string](filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.Error(t, err)
+}
+
+func TestCSVDataset_InputColumn(t *testing.T) {
+	t.Parallel()
+
+	input := `question,answer,tags,source
+"{""question"": ""2+2?""}","{""answer"": ""4""}","math, easy",textbook
+"{""question"": ""capital?""}",,,
+`
+	ds := NewCSVDataset[testDatasetInput, testDatasetOutput](strings.NewReader(input), CSVColumns{
+		Input:    "question",
+		Expected: "answer",
+		Tags:     "tags",
+		Metadata: []string{"source"},
+	})
+
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	require.Len(t, cases, 2)
+
+	assert.Equal(t, testDatasetInput{Question: "2+2?"}, cases[0].Input)
+	assert.Equal(t, testDatasetOutput{Answer: "4"}, cases[0].Expected)
+	assert.Equal(t, []string{"math", "easy"}, cases[0].Tags)
+	assert.Equal(t, map[string]interface{}{"source": "textbook"}, cases[0].Metadata)
+
+	assert.Equal(t, testDatasetOutput{}, cases[1].Expected)
+	assert.Nil(t, cases[1].Tags)
+}
+
+func TestCSVDataset_InputFromColumns(t *testing.T) {
+	t.Parallel()
+
+	input := `question,answer
+2+2?,4
+"capital of France?",Paris
+`
+	ds := NewCSVDataset[testDatasetInput, string](strings.NewReader(input), CSVColumns{Expected: "answer"})
+
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	require.Len(t, cases, 2)
+	assert.Equal(t, testDatasetInput{Question: "2+2?"}, cases[0].Input)
+	assert.Equal(t, "4", cases[0].Expected)
+	assert.Equal(t, testDatasetInput{Question: "capital of France?"}, cases[1].Input)
+	assert.Equal(t, "Paris", cases[1].Expected)
+}
+
+func TestCSVDataset_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing column", func(t *testing.T) {
+		ds := NewCSVDataset[string, string](strings.NewReader("a,b\n1,2\n"), CSVColumns{Input: "missing"})
+		cases, errs := readAll(t, ds)
+		assert.Empty(t, cases)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), `CSV column "missing" not found`)
+	})
+
+	t.Run("wrong number of columns", func(t *testing.T) {
+		ds := NewCSVDataset[string, string](strings.NewReader("a,b\n1,2\n3\n4,5\n"), CSVColumns{Input: "a", Expected: "b"})
+		cases, errs := readAll(t, ds)
+		require.Len(t, cases, 2)
+		assert.Equal(t, "4", cases[1].Input)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "line 3: expected 2 columns, got 1")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		ds := NewCSVDataset[string, string](strings.NewReader(""), CSVColumns{Input: "a"})
+		cases, errs := readAll(t, ds)
+		assert.Empty(t, cases)
+		assert.Empty(t, errs)
+	})
+}
+
+func TestOpenCSVDataset(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "golden.csv")
+	require.NoError(t, os.WriteFile(path, []byte("in,out\n1,2\n3,4\n"), 0o600))
+
+	ds, err := OpenCSVDataset[int, int](path, CSVColumns{Input: "in", Expected: "out"})
+	require.NoError(t, err)
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	require.Len(t, cases, 2)
+	assert.Equal(t, 3, cases[1].Input)
+	assert.Equal(t, 4, cases[1].Expected)
+}
@@ -0,0 +1,299 @@
+package eval
+
+import (
+	"io"
+	"math/rand"
+)
+
+// Filter returns a Dataset with only the cases of ds for which keep returns true.
+// Errors from ds are passed through.
+func Filter[I, R any](ds Dataset[I, R], keep func(Case[I, R]) bool) Dataset[I, R] {
+	return &filterCases[I, R]{Dataset: ds, keep: keep}
+}
+
+// FilterTags returns a Dataset with only the cases of ds that have at least one of tags.
+func FilterTags[I, R any](ds Dataset[I, R], tags ...string) Dataset[I, R] {
+	want := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		want[t] = true
+	}
+	return Filter(ds, func(c Case[I, R]) bool {
+		for _, t := range c.Tags {
+			if want[t] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterCases implements the Dataset interface for Filter.
+type filterCases[I, R any] struct {
+	Dataset[I, R]
+	keep func(Case[I, R]) bool
+}
+
+// Next returns the next case that passes the filter, or io.EOF if there are no more cases.
+func (f *filterCases[I, R]) Next() (Case[I, R], error) {
+	for {
+		c, err := f.Dataset.Next()
+		if err != nil || f.keep(c) {
+			return c, err
+		}
+	}
+}
+
+// Limit returns a Dataset with at most the first n cases of ds.
+// Errors from ds count towards the limit.
+func Limit[I, R any](ds Dataset[I, R], n int) Dataset[I, R] {
+	return &limitCases[I, R]{Dataset: ds, n: n}
+}
+
+// limitCases implements the Dataset interface for Limit.
+type limitCases[I, R any] struct {
+	Dataset[I, R]
+	n    int
+	read int
+}
+
+// Next returns the next case, or io.EOF once n cases have been read.
+func (l *limitCases[I, R]) Next() (Case[I, R], error) {
+	if l.read >= l.n {
+		var zero Case[I, R]
+		return zero, io.EOF
+	}
+	c, err := l.Dataset.Next()
+	if err != io.EOF {
+		l.read++
+	}
+	return c, err
+}
+
+// Len returns the number of cases in the dataset, or -1 if it's unknown.
+func (l *limitCases[I, R]) Len() int {
+	n := datasetLen(l.Dataset)
+	if n < 0 {
+		return -1
+	}
+	return minInt(n, maxInt(l.n, 0))
+}
+
+// Shuffle returns a Dataset with the cases of ds in a random order determined by seed.
+// The same seed always produces the same order. ds is read completely on the first call to Next.
+// If ds returns an error, reading stops there, and the error is returned after the cases read before it.
+func Shuffle[I, R any](ds Dataset[I, R], seed int64) Dataset[I, R] {
+	return &bufferedCases[I, R]{
+		Dataset: ds,
+		prepare: func(entries []datasetEntry[I, R]) []datasetEntry[I, R] {
+			rng := rand.New(rand.NewSource(seed))
+			rng.Shuffle(len(entries), func(i, j int) {
+				entries[i], entries[j] = entries[j], entries[i]
+			})
+			return entries
+		},
+	}
+}
+
+// Sample returns a Dataset with n cases of ds chosen at random, keeping their original order.
+// The same seed always selects the same cases. If ds has n or fewer cases, all of them are
+// returned. ds is read completely on the first call to Next. If ds returns an error, reading
+// stops there, and the error is returned after the cases sampled from those read before it.
+func Sample[I, R any](ds Dataset[I, R], n int, seed int64) Dataset[I, R] {
+	return &bufferedCases[I, R]{
+		Dataset: ds,
+		prepare: func(entries []datasetEntry[I, R]) []datasetEntry[I, R] {
+			if n >= len(entries) {
+				return entries
+			}
+			if n <= 0 {
+				return nil
+			}
+			rng := rand.New(rand.NewSource(seed))
+			picked := rng.Perm(len(entries))[:n]
+			keep := make([]bool, len(entries))
+			for _, i := range picked {
+				keep[i] = true
+			}
+			sampled := make([]datasetEntry[I, R], 0, n)
+			for i, e := range entries {
+				if keep[i] {
+					sampled = append(sampled, e)
+				}
+			}
+			return sampled
+		},
+	}
+}
+
+// datasetEntry is a case or iterator error read from a dataset.
+type datasetEntry[I, R any] struct {
+	c   Case[I, R]
+	err error
+}
+
+// bufferedCases implements the Dataset interface for combinators that need to
+// read the whole dataset before returning the first case.
+type bufferedCases[I, R any] struct {
+	Dataset[I, R]
+	prepare func([]datasetEntry[I, R]) []datasetEntry[I, R]
+	entries []datasetEntry[I, R]
+	loaded  bool
+	index   int
+}
+
+// Next returns the next case, or io.EOF if there are no more cases.
+func (b *bufferedCases[I, R]) Next() (Case[I, R], error) {
+	b.load()
+	if b.index >= len(b.entries) {
+		var zero Case[I, R]
+		return zero, io.EOF
+	}
+	e := b.entries[b.index]
+	b.index++
+	return e.c, e.err
+}
+
+// Len returns the number of cases in the dataset.
+func (b *bufferedCases[I, R]) Len() int {
+	b.load()
+	return len(b.entries)
+}
+
+func (b *bufferedCases[I, R]) load() {
+	if b.loaded {
+		return
+	}
+	b.loaded = true
+	var entries []datasetEntry[I, R]
+	var failed *datasetEntry[I, R]
+	for {
+		c, err := b.Dataset.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// a source that keeps failing would never return io.EOF, so stop at its first error
+			failed = &datasetEntry[I, R]{err: err}
+			break
+		}
+		entries = append(entries, datasetEntry[I, R]{c: c})
+	}
+	b.entries = b.prepare(entries)
+	if failed != nil {
+		b.entries = append(b.entries, *failed)
+	}
+}
+
+// Concat returns a Dataset with the cases of each dataset in turn.
+//
+// ID and Version return the ID and version shared by all datasets, or empty string
+// if they differ. Cases from Braintrust datasets keep their link to their own dataset.
+func Concat[I, R any](datasets ...Dataset[I, R]) Dataset[I, R] {
+	return &concatCases[I, R]{datasets: datasets}
+}
+
+// concatCases implements the Dataset interface for Concat.
+type concatCases[I, R any] struct {
+	datasets []Dataset[I, R]
+	index    int
+}
+
+// Next returns the next case, or io.EOF if all datasets are exhausted.
+func (cc *concatCases[I, R]) Next() (Case[I, R], error) {
+	for cc.index < len(cc.datasets) {
+		ds := cc.datasets[cc.index]
+		c, err := ds.Next()
+		if err == io.EOF {
+			cc.index++
+			continue
+		}
+		if c.datasetID == "" {
+			c.datasetID = ds.ID()
+		}
+		return c, err
+	}
+	var zero Case[I, R]
+	return zero, io.EOF
+}
+
+// Len returns the total number of cases, or -1 if it's unknown for any dataset.
+func (cc *concatCases[I, R]) Len() int {
+	total := 0
+	for _, ds := range cc.datasets {
+		n := datasetLen(ds)
+		if n < 0 {
+			return -1
+		}
+		total += n
+	}
+	return total
+}
+
+// ID returns the ID shared by all datasets, or empty string if they differ.
+func (cc *concatCases[I, R]) ID() string {
+	return cc.shared(Dataset[I, R].ID)
+}
+
+// Version returns the version shared by all datasets, or empty string if they differ.
+func (cc *concatCases[I, R]) Version() string {
+	return cc.shared(Dataset[I, R].Version)
+}
+
+func (cc *concatCases[I, R]) shared(get func(Dataset[I, R]) string) string {
+	if len(cc.datasets) == 0 {
+		return ""
+	}
+	v := get(cc.datasets[0])
+	for _, ds := range cc.datasets[1:] {
+		if get(ds) != v {
+			return ""
+		}
+	}
+	return v
+}
+
+// MapInput returns a Dataset with the input of every case of ds converted by fn.
+// All other fields of the cases are unchanged.
+func MapInput[I, J, R any](ds Dataset[I, R], fn func(I) J) Dataset[J, R] {
+	return &mapCases[I, J, R]{ds: ds, fn: fn}
+}
+
+// mapCases implements the Dataset interface for MapInput.
+type mapCases[I, J, R any] struct {
+	ds Dataset[I, R]
+	fn func(I) J
+}
+
+// Next returns the next case, or io.EOF if there are no more cases.
+func (m *mapCases[I, J, R]) Next() (Case[J, R], error) {
+	c, err := m.ds.Next()
+	if err != nil {
+		var zero Case[J, R]
+		return zero, err
+	}
+	return Case[J, R]{
+		Input:     m.fn(c.Input),
+		Expected:  c.Expected,
+		Tags:      c.Tags,
+		Metadata:  c.Metadata,
+		ID:        c.ID,
+		XactID:    c.XactID,
+		Created:   c.Created,
+		datasetID: c.datasetID,
+	}, nil
+}
+
+// Len returns the number of cases in the dataset, or -1 if it's unknown.
+func (m *mapCases[I, J, R]) Len() int {
+	return datasetLen(m.ds)
+}
+
+// ID returns the ID of the underlying dataset.
+func (m *mapCases[I, J, R]) ID() string {
+	return m.ds.ID()
+}
+
+// Version returns the version of the underlying dataset.
+func (m *mapCases[I, J, R]) Version() string {
+	return m.ds.Version()
+}
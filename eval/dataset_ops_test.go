@@ -0,0 +1,275 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberCases returns n cases with inputs 0..n-1, tagging even inputs "even".
+func numberCases(n int) []Case[int, string] {
+	cases := make([]Case[int, string], n)
+	for i := range cases {
+		cases[i] = Case[int, string]{Input: i, Expected: strconv.Itoa(i)}
+		if i%2 == 0 {
+			cases[i].Tags = []string{"even"}
+		}
+	}
+	return cases
+}
+
+func inputs[I, R any](cases []Case[I, R]) []I {
+	out := make([]I, len(cases))
+	for i, c := range cases {
+		out[i] = c.Input
+	}
+	return out
+}
+
+// remoteDataset is a Dataset with an ID and version, like one loaded from Braintrust.
+type remoteDataset[I, R any] struct {
+	Dataset[I, R]
+	id      string
+	version string
+}
+
+func (r *remoteDataset[I, R]) ID() string      { return r.id }
+func (r *remoteDataset[I, R]) Version() string { return r.version }
+func (r *remoteDataset[I, R]) Len() int        { return datasetLen(r.Dataset) }
+
+// errDataset returns err after its cases.
+type errDataset[I, R any] struct {
+	Dataset[I, R]
+	err  error
+	done bool
+}
+
+func (e *errDataset[I, R]) Next() (Case[I, R], error) {
+	c, err := e.Dataset.Next()
+	if err == io.EOF && !e.done {
+		e.done = true
+		return c, e.err
+	}
+	return c, err
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	ds := Filter(NewDataset(numberCases(10)), func(c Case[int, string]) bool {
+		return c.Input > 6
+	})
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	assert.Equal(t, []int{7, 8, 9}, inputs(cases))
+	assert.Equal(t, -1, datasetLen(ds))
+}
+
+func TestFilterTags(t *testing.T) {
+	t.Parallel()
+
+	cases, errs := readAll(t, FilterTags(NewDataset(numberCases(6)), "even", "missing"))
+	assert.Empty(t, errs)
+	assert.Equal(t, []int{0, 2, 4}, inputs(cases))
+
+	cases, _ = readAll(t, FilterTags(NewDataset(numberCases(6))))
+	assert.Empty(t, cases)
+}
+
+func TestFilter_PassesThroughErrors(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	ds := Filter[int, string](&errDataset[int, string]{Dataset: NewDataset(numberCases(3)), err: boom}, func(Case[int, string]) bool {
+		return false
+	})
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, cases)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], boom)
+}
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	ds := Limit(NewDataset(numberCases(10)), 3)
+	assert.Equal(t, 3, datasetLen(ds))
+	cases, _ := readAll(t, ds)
+	assert.Equal(t, []int{0, 1, 2}, inputs(cases))
+
+	ds = Limit(NewDataset(numberCases(2)), 5)
+	assert.Equal(t, 2, datasetLen(ds))
+	cases, _ = readAll(t, ds)
+	assert.Equal(t, []int{0, 1}, inputs(cases))
+
+	cases, _ = readAll(t, Limit(NewDataset(numberCases(2)), 0))
+	assert.Empty(t, cases)
+}
+
+func TestShuffle(t *testing.T) {
+	t.Parallel()
+
+	first, _ := readAll(t, Shuffle(NewDataset(numberCases(20)), 42))
+	second, _ := readAll(t, Shuffle(NewDataset(numberCases(20)), 42))
+	other, _ := readAll(t, Shuffle(NewDataset(numberCases(20)), 7))
+
+	assert.Equal(t, inputs(first), inputs(second), "same seed should give the same order")
+	assert.NotEqual(t, inputs(first), inputs(other), "different seeds should give different orders")
+	assert.ElementsMatch(t, inputs(numberCases(20)), inputs(first))
+	assert.Equal(t, 20, datasetLen(Shuffle(NewDataset(numberCases(20)), 42)))
+}
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	first, _ := readAll(t, Sample(NewDataset(numberCases(100)), 10, 1))
+	second, _ := readAll(t, Sample(NewDataset(numberCases(100)), 10, 1))
+	require.Len(t, first, 10)
+	assert.Equal(t, inputs(first), inputs(second))
+	assert.IsIncreasing(t, inputs(first), "sampled cases should keep dataset order")
+
+	all, _ := readAll(t, Sample(NewDataset(numberCases(5)), 10, 1))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, inputs(all))
+
+	none, _ := readAll(t, Sample(NewDataset(numberCases(5)), 0, 1))
+	assert.Empty(t, none)
+}
+
+// failingDataset fails on every call, like a Braintrust dataset while the API is down.
+type failingDataset[I, R any] struct {
+	Dataset[I, R]
+	err   error
+	calls int
+}
+
+func (f *failingDataset[I, R]) Next() (Case[I, R], error) {
+	f.calls++
+	return Case[I, R]{}, f.err
+}
+
+func TestBufferedCases_StopAtError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("api down")
+	for name, buffer := range map[string]func(Dataset[int, string]) Dataset[int, string]{
+		"shuffle": func(ds Dataset[int, string]) Dataset[int, string] { return Shuffle(ds, 42) },
+		"sample":  func(ds Dataset[int, string]) Dataset[int, string] { return Sample(ds, 2, 1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			failing := &failingDataset[int, string]{Dataset: NewDataset[int, string](nil), err: boom}
+			cases, errs := readAll(t, buffer(failing))
+			assert.Empty(t, cases)
+			assert.Equal(t, []error{boom}, errs)
+			assert.Equal(t, 1, failing.calls)
+
+			// the cases read before the error are returned before it
+			ds := buffer(&errDataset[int, string]{Dataset: NewDataset(numberCases(3)), err: boom})
+			var got []error
+			for {
+				_, err := ds.Next()
+				if err == io.EOF {
+					break
+				}
+				got = append(got, err)
+			}
+			require.NotEmpty(t, got)
+			assert.Equal(t, boom, got[len(got)-1])
+			assert.Equal(t, len(got), datasetLen(ds))
+		})
+	}
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	a := &remoteDataset[int, string]{Dataset: NewDataset(numberCases(2)), id: "ds-a", version: "1"}
+	b := &remoteDataset[int, string]{Dataset: NewDataset(numberCases(3)), id: "ds-b", version: "1"}
+	ds := Concat[int, string](a, b)
+
+	assert.Empty(t, ds.ID(), "datasets with different IDs have no shared ID")
+	assert.Equal(t, "1", ds.Version())
+	assert.Equal(t, 5, datasetLen(ds))
+	assert.Equal(t, -1, datasetLen(Concat(ds, Filter(NewDataset(numberCases(1)), func(Case[int, string]) bool { return true }))))
+
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	assert.Equal(t, []int{0, 1, 0, 1, 2}, inputs(cases))
+	assert.Equal(t, "ds-a", cases[0].datasetID)
+	assert.Equal(t, "ds-b", cases[4].datasetID)
+
+	same := Concat(NewDataset(numberCases(1)), NewDataset(numberCases(2)))
+	assert.Empty(t, same.ID())
+	assert.Equal(t, 3, datasetLen(same))
+	assert.Empty(t, Concat[int, string]().ID())
+}
+
+func TestMapInput(t *testing.T) {
+	t.Parallel()
+
+	src := &remoteDataset[int, string]{Dataset: NewDataset(numberCases(3)), id: "ds-1", version: "v2"}
+	ds := MapInput[int, string, string](src, strconv.Itoa)
+	assert.Equal(t, "ds-1", ds.ID())
+	assert.Equal(t, "v2", ds.Version())
+	assert.Equal(t, 3, datasetLen(ds))
+
+	cases, errs := readAll(t, ds)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"0", "1", "2"}, inputs(cases))
+	assert.Equal(t, "2", cases[2].Expected)
+	assert.Equal(t, []string{"even"}, cases[2].Tags)
+}
+
+func TestCombinators_PassThroughIDAndVersion(t *testing.T) {
+	t.Parallel()
+
+	src := func() Dataset[int, string] {
+		return &remoteDataset[int, string]{Dataset: NewDataset(numberCases(4)), id: "ds-1", version: "v1"}
+	}
+	for name, ds := range map[string]Dataset[int, string]{
+		"filter":  Filter(src(), func(Case[int, string]) bool { return true }),
+		"tags":    FilterTags(src(), "even"),
+		"limit":   Limit(src(), 1),
+		"shuffle": Shuffle(src(), 1),
+		"sample":  Sample(src(), 2, 1),
+		"concat":  Concat(src(), src()),
+	} {
+		assert.Equal(t, "ds-1", ds.ID(), name)
+		assert.Equal(t, "v1", ds.Version(), name)
+	}
+}
+
+func TestConcat_OriginUsesSourceDataset(t *testing.T) {
+	t.Parallel()
+
+	row := func(id string) []Case[testInput, testOutput] {
+		return []Case[testInput, testOutput]{{Input: testInput{Value: id}, ID: id, XactID: "x-" + id}}
+	}
+	ds := Concat[testInput, testOutput](
+		&remoteDataset[testInput, testOutput]{Dataset: NewDataset(row("a")), id: "ds-a"},
+		&remoteDataset[testInput, testOutput]{Dataset: NewDataset(row("b")), id: "ds-b"},
+	)
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	testEval := newUnitTestEval(t, ds, task, nil, 1)
+	_, err := testEval.eval.run(context.Background())
+	require.NoError(t, err)
+
+	origins := map[string]string{}
+	for _, span := range testEval.exporter.Flush() {
+		if span.Name() != "eval" {
+			continue
+		}
+		var origin map[string]any
+		require.NoError(t, json.Unmarshal([]byte(span.Attr("braintrust.origin").String()), &origin))
+		origins[origin["id"].(string)] = origin["object_id"].(string)
+	}
+	assert.Equal(t, map[string]string{"a": "ds-a", "b": "ds-b"}, origins)
+}
@@ -0,0 +1,107 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/braintrustdata/braintrust-sdk-go/api/datasets"
+	"github.com/braintrustdata/braintrust-sdk-go/api/projects"
+)
+
+// uploadBatchSize is the number of rows inserted per request by [DatasetAPI.Upload].
+const uploadBatchSize = 100
+
+// DatasetUploadOpts contains options for uploading cases to a dataset.
+type DatasetUploadOpts[I any] struct {
+	// Name is the dataset name (required)
+	Name string
+
+	// Project overrides the default project name (optional)
+	Project string
+
+	// Description is set when the dataset is created (optional)
+	Description string
+
+	// CaseID returns the ID of a case's row. Use the same function as [Opts.CaseID], so evals
+	// of the uploaded dataset match the experiments of the cases it was uploaded from.
+	// (default: a SHA-256 hash of the input's canonical JSON, like [Opts.CaseID])
+	CaseID func(input I) string
+}
+
+// Upload writes cases to the dataset with the given name, creating the dataset if it
+// doesn't exist, and returns the dataset so it can be used in an eval.
+//
+// Each row's ID is the case ID given by [DatasetUploadOpts.CaseID], or the case's dataset
+// record ID if it has one, so uploading the same cases again updates the existing rows
+// instead of adding duplicates. Rows are merged into existing rows, so fields that are
+// removed from a case are kept in the dataset. Cases with the same ID are written to the
+// same row, and the last one wins.
+func (d *DatasetAPI[I, R]) Upload(ctx context.Context, opts DatasetUploadOpts[I], cases []Case[I, R]) (Dataset[I, R], error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("dataset name is required")
+	}
+
+	projectName := d.projectName
+	if opts.Project != "" {
+		projectName = opts.Project
+	}
+	if projectName == "" {
+		return nil, fmt.Errorf("project name is required (set via WithProject option or DatasetUploadOpts.Project)")
+	}
+
+	events, err := uploadEvents(opts.CaseID, cases)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := d.api.Projects().Create(ctx, projects.CreateParams{Name: projectName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	// Creating a dataset returns the existing dataset if one has the same name.
+	ds, err := d.api.Datasets().Create(ctx, datasets.CreateParams{
+		ProjectID:   project.ID,
+		Name:        opts.Name,
+		Description: opts.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dataset: %w", err)
+	}
+
+	for start := 0; start < len(events); start += uploadBatchSize {
+		end := minInt(start+uploadBatchSize, len(events))
+		if err := d.api.Datasets().InsertEvents(ctx, ds.ID, events[start:end]); err != nil {
+			return nil, fmt.Errorf("failed to insert dataset rows: %w", err)
+		}
+	}
+
+	return d.Get(ctx, ds.ID)
+}
+
+// uploadEvents converts cases to dataset events with their case IDs as IDs.
+func uploadEvents[I, R any](caseID func(input I) string, cases []Case[I, R]) ([]datasets.Event, error) {
+	isMerge := true
+	events := make([]datasets.Event, 0, len(cases))
+	for i, c := range cases {
+		id := c.ID
+		if id == "" {
+			id = caseIDOf(caseID, c)
+		}
+		if id == "" {
+			if _, err := inputHash(c.Input); err != nil {
+				return nil, fmt.Errorf("case %d: %w", i, err)
+			}
+			return nil, fmt.Errorf("case %d: empty case ID", i)
+		}
+		events = append(events, datasets.Event{
+			ID:       id,
+			Input:    c.Input,
+			Expected: c.Expected,
+			Metadata: c.Metadata,
+			Tags:     c.Tags,
+			IsMerge:  &isMerge,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,174 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/api"
+	"github.com/braintrustdata/braintrust-sdk-go/internal/https"
+)
+
+// fakeDatasetServer is an in-memory dataset API that upserts inserted rows by ID.
+type fakeDatasetServer struct {
+	mu       sync.Mutex
+	rows     map[string]map[string]any
+	order    []string
+	inserts  int
+	projects []string
+	datasets []map[string]any
+}
+
+func newFakeDatasetServer(t *testing.T) (*fakeDatasetServer, *api.API) {
+	t.Helper()
+
+	f := &fakeDatasetServer{rows: map[string]map[string]any{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		var resp any
+		switch r.URL.Path {
+		case "/v1/project":
+			f.projects = append(f.projects, body["name"].(string))
+			resp = map[string]any{"id": "proj-1", "name": body["name"]}
+		case "/v1/dataset":
+			f.datasets = append(f.datasets, body)
+			resp = map[string]any{"id": "ds-1", "project_id": body["project_id"], "name": body["name"]}
+		case "/v1/dataset/ds-1/insert":
+			f.inserts++
+			for _, e := range body["events"].([]any) {
+				event := e.(map[string]any)
+				id := event["id"].(string)
+				if _, ok := f.rows[id]; !ok {
+					f.order = append(f.order, id)
+				}
+				event["_xact_id"] = "xact-1"
+				f.rows[id] = event
+			}
+			resp = map[string]any{"row_ids": []string{}}
+		case "/v1/dataset/ds-1/fetch":
+			events := make([]map[string]any, 0, len(f.order))
+			for _, id := range f.order {
+				events = append(events, f.rows[id])
+			}
+			resp = map[string]any{"events": events, "cursor": ""}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustJSON(t, resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return f, api.NewWithHTTPSClient(https.NewClient("test-key", server.URL, nil))
+}
+
+func TestDatasetAPI_Upload(t *testing.T) {
+	t.Parallel()
+
+	fake, apiClient := newFakeDatasetServer(t)
+	datasetAPI := &DatasetAPI[testDatasetInput, testDatasetOutput]{api: apiClient, projectName: "default-project"}
+	ctx := context.Background()
+
+	cases := []Case[testDatasetInput, testDatasetOutput]{
+		{Input: testDatasetInput{Question: "2+2?"}, Expected: testDatasetOutput{Answer: "4"}, Tags: []string{"math"}},
+		{Input: testDatasetInput{Question: "capital of France?"}, Expected: testDatasetOutput{Answer: "Paris"}},
+	}
+
+	ds, err := datasetAPI.Upload(ctx, DatasetUploadOpts[testDatasetInput]{Name: "my-dataset", Description: "uploaded"}, cases)
+	require.NoError(t, err)
+	assert.Equal(t, "ds-1", ds.ID())
+	assert.Equal(t, []string{"default-project"}, fake.projects)
+	require.Len(t, fake.datasets, 1)
+	assert.Equal(t, "my-dataset", fake.datasets[0]["name"])
+	assert.Equal(t, "proj-1", fake.datasets[0]["project_id"])
+	assert.Equal(t, "uploaded", fake.datasets[0]["description"])
+
+	uploaded, errs := readAll(t, ds)
+	require.Empty(t, errs)
+	require.Len(t, uploaded, 2)
+	assert.Equal(t, cases[0].Input, uploaded[0].Input)
+	assert.Equal(t, cases[0].Expected, uploaded[0].Expected)
+	assert.Equal(t, []string{"math"}, uploaded[0].Tags)
+	assert.NotEmpty(t, uploaded[0].ID)
+	for _, row := range fake.rows {
+		assert.Equal(t, true, row["_is_merge"])
+	}
+
+	// Uploading again updates the existing rows instead of adding new ones.
+	cases[1].Expected = testDatasetOutput{Answer: "Paris, France"}
+	cases = append(cases, Case[testDatasetInput, testDatasetOutput]{Input: testDatasetInput{Question: "new?"}})
+	ds, err = datasetAPI.Upload(ctx, DatasetUploadOpts[testDatasetInput]{Name: "my-dataset", Project: "other-project"}, cases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default-project", "other-project"}, fake.projects)
+
+	uploaded, errs = readAll(t, ds)
+	require.Empty(t, errs)
+	require.Len(t, uploaded, 3)
+	assert.Equal(t, "Paris, France", uploaded[1].Expected.Answer)
+	assert.Equal(t, "new?", uploaded[2].Input.Question)
+}
+
+func TestDatasetAPI_Upload_Batches(t *testing.T) {
+	t.Parallel()
+
+	fake, apiClient := newFakeDatasetServer(t)
+	datasetAPI := &DatasetAPI[int, int]{api: apiClient, projectName: "p"}
+
+	cases := make([]Case[int, int], uploadBatchSize*2+1)
+	for i := range cases {
+		cases[i] = Case[int, int]{Input: i, Expected: i * 2}
+	}
+	_, err := datasetAPI.Upload(context.Background(), DatasetUploadOpts[int]{Name: "numbers"}, cases)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.inserts)
+	assert.Len(t, fake.rows, len(cases))
+}
+
+func TestDatasetAPI_Upload_CaseID(t *testing.T) {
+	t.Parallel()
+
+	fake, apiClient := newFakeDatasetServer(t)
+	datasetAPI := &DatasetAPI[string, string]{api: apiClient, projectName: "p"}
+	cases := []Case[string, string]{{Input: "a"}, {Input: "b", ID: "row-b"}}
+
+	// rows are keyed like the eval keys the cases they were uploaded from
+	_, err := datasetAPI.Upload(context.Background(), DatasetUploadOpts[string]{Name: "default"}, cases)
+	require.NoError(t, err)
+	require.Len(t, fake.rows, 2)
+	assert.Contains(t, fake.rows, caseIDOf(nil, cases[0]))
+	assert.Contains(t, fake.rows, "row-b")
+
+	caseID := func(input string) string { return "case-" + input }
+	_, err = datasetAPI.Upload(context.Background(), DatasetUploadOpts[string]{Name: "custom", CaseID: caseID}, cases[:1])
+	require.NoError(t, err)
+	require.Len(t, fake.rows, 3)
+	assert.Contains(t, fake.rows, "case-a")
+	assert.Equal(t, matchKey("", caseIDOf(caseID, cases[0])), matchKey("case-a", ""))
+
+	_, err = datasetAPI.Upload(context.Background(), DatasetUploadOpts[string]{Name: "empty", CaseID: func(string) string { return "" }}, cases[:1])
+	assert.ErrorContains(t, err, "case 0: empty case ID")
+}
+
+func TestDatasetAPI_Upload_Validation(t *testing.T) {
+	t.Parallel()
+
+	datasetAPI := &DatasetAPI[string, string]{}
+	_, err := datasetAPI.Upload(context.Background(), DatasetUploadOpts[string]{}, nil)
+	assert.ErrorContains(t, err, "dataset name is required")
+
+	_, err = datasetAPI.Upload(context.Background(), DatasetUploadOpts[string]{Name: "ds"}, nil)
+	assert.ErrorContains(t, err, "project name is required")
+}
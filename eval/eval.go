@@ -31,7 +31,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -40,8 +42,10 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/braintrustdata/braintrust-sdk-go/api"
+	"github.com/braintrustdata/braintrust-sdk-go/api/experiments"
 	"github.com/braintrustdata/braintrust-sdk-go/internal/auth"
 	bttrace "github.com/braintrustdata/braintrust-sdk-go/trace"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/pricing"
 )
 
 var (
@@ -50,6 +54,8 @@ var (
 	errScorer       = errors.New("scorer error")
 	errTaskRun      = errors.New("task run error")
 	errCaseIterator = errors.New("case iterator error")
+	errTimeout      = errors.New("timeout")
+	errAborted      = errors.New("eval aborted")
 )
 
 var (
@@ -59,6 +65,11 @@ var (
 	scoreSpanAttrs = map[string]any{"type": "score"}
 )
 
+// scorerSpanAttrs returns the braintrust "span_attributes" for the child span of a single scorer.
+func scorerSpanAttrs(name string) map[string]any {
+	return map[string]any{"type": "score", "name": name}
+}
+
 // Opts defines the options for running an evaluation.
 // I is the input type and R is the result/output type.
 //
@@ -84,6 +95,56 @@ type Opts[I, R any] struct {
 	Update      bool     // If true, append to existing experiment (default: false)
 	Parallelism int      // Number of goroutines (default: 1)
 	Quiet       bool     // Suppress result output (default: false)
+
+	// BaseExperiment is the name of an experiment in the same project to compare against.
+	// BaseExperimentID can be used instead to select the experiment by ID.
+	// When set, [Result.Comparison] reports score changes and regressed cases.
+	BaseExperiment   string
+	BaseExperimentID string
+
+	// CaseTimeout bounds how long a single case (task and scorers) may run.
+	// ScorerTimeout bounds each scorer call. Cases that time out are recorded as errors
+	// and counted by [Result.Timeouts]. Zero means no timeout (default).
+	CaseTimeout   time.Duration
+	ScorerTimeout time.Duration
+
+	// Trials is the number of times each case is run (default: 1). Each trial is logged
+	// as a separate eval span, and [Result.Trials] reports score statistics per case.
+	Trials int
+
+	// ErrorBudget stops the eval early when too many cases fail. (default: never stop)
+	ErrorBudget ErrorBudget
+
+	// Progress receives progress events while the eval runs. If nil and Quiet is false,
+	// a progress bar is written to stderr when it is a terminal.
+	Progress Progress
+
+	// Retry configures retries of failed tasks. Retries are recorded as events on the task span.
+	// The case timeout applies to all attempts together. (default: no retries)
+	Retry RetryPolicy
+
+	// ScorerParallelism is the number of scorers run concurrently for each case (default: 1).
+	// When greater than 1, each scorer runs in its own child span of the score span, and
+	// scorers must be safe for concurrent use.
+	ScorerParallelism int
+
+	// CaseID returns a stable identity for a case that isn't from a Braintrust dataset, such as
+	// the cases of [NewDataset]. It is recorded as "case_id" in the eval span's metadata and used
+	// to match cases across experiments for [Opts.BaseExperiment] and [Opts.Resume]. Dataset
+	// cases are matched by record ID instead. (default: a SHA-256 hash of the input's canonical JSON)
+	CaseID func(input I) string
+
+	// Resume reuses an existing experiment, like Update, and skips the cases that were
+	// already logged to it without errors, so an interrupted eval can be finished without
+	// running every case again. Cases are matched by dataset record ID, or by [Opts.CaseID]
+	// for in-memory datasets. Skipped cases are counted by [Result.Resumed] and are not
+	// included in the result's cases or scores.
+	Resume bool
+
+	// Limiter limits the rate and concurrency of task and hosted scorer calls. It can be
+	// shared by several evals running at once. The time spent waiting on it is recorded
+	// on the task and score spans. (default: no limit)
+	Limiter *Limiter
 }
 
 // Case represents a single test case in an evaluation.
@@ -108,6 +169,8 @@ type Case[I, R any] struct {
 	ID      string // Dataset record ID
 	XactID  string // Transaction ID
 	Created string // Creation timestamp
+
+	datasetID string // Source dataset ID when it differs from the eval's dataset, see [Concat]
 }
 
 // Dataset is an iterator interface for evaluation datasets. It is commonly
@@ -130,10 +193,15 @@ type Metadata map[string]any
 
 // Result contains the results of an evaluation.
 type Result struct {
-	key       key
-	err       error
-	elapsed   time.Duration
-	permalink string
+	key        key
+	err        error
+	elapsed    time.Duration
+	permalink  string
+	cases      []CaseResult
+	scores     []ScoreSummary
+	comparison *Comparison
+	aborted    error
+	resumed    int
 }
 
 // key contains the data needed to uniquely identify and reference an eval.
@@ -146,12 +214,14 @@ type key struct {
 }
 
 // newResult creates a new Result with the given parameters.
-func newResult(k key, err error, permalink string, elapsed time.Duration) *Result {
+func newResult(k key, err error, permalink string, elapsed time.Duration, cases []CaseResult) *Result {
 	return &Result{
 		err:       err,
 		permalink: permalink,
 		elapsed:   elapsed,
 		key:       k,
+		cases:     cases,
+		scores:    summarizeScores(cases),
 	}
 }
 
@@ -175,6 +245,74 @@ func (r *Result) ID() string {
 	return r.key.experimentID
 }
 
+// Scores returns summary statistics for each score recorded in the eval, sorted by name.
+func (r *Result) Scores() []ScoreSummary {
+	return r.scores
+}
+
+// Score returns the summary statistics for the score with the given name.
+// The second return value is false if no such score was recorded.
+func (r *Result) Score(name string) (ScoreSummary, bool) {
+	for _, s := range r.scores {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return ScoreSummary{}, false
+}
+
+// Aborted returns true if the eval was stopped early because its [ErrorBudget] was exceeded
+// or its context was canceled. The result then only contains the cases that finished.
+func (r *Result) Aborted() bool {
+	return r.aborted != nil
+}
+
+// Resumed returns the number of cases (including trials) that were skipped because they were
+// already logged to the experiment when [Opts.Resume] is set.
+func (r *Result) Resumed() int {
+	return r.resumed
+}
+
+// Timeouts returns the number of cases where the task or a scorer timed out.
+func (r *Result) Timeouts() int {
+	n := 0
+	for _, c := range r.cases {
+		if c.TimedOut {
+			n++
+		}
+	}
+	return n
+}
+
+// Cost returns the estimated total cost in US dollars of the LLM calls made by the tasks and
+// scorers of the eval. See [CaseResult.Cost].
+func (r *Result) Cost() float64 {
+	var total float64
+	for _, c := range r.cases {
+		total += c.Cost
+	}
+	return total
+}
+
+// Trials returns score statistics over the trials of each case, in dataset order.
+// There is one TrialSummary per case, even if each case was only run once.
+func (r *Result) Trials() []TrialSummary {
+	return summarizeTrials(r.cases)
+}
+
+// Comparison returns the comparison with the base experiment, or nil if
+// no base experiment was set in [Opts].
+func (r *Result) Comparison() *Comparison {
+	return r.comparison
+}
+
+// Cases returns the result of each case in the eval, in dataset order.
+// When [Opts.Trials] is greater than 1, each trial of a case has its own CaseResult.
+// Cases that could not be read from the dataset are not included.
+func (r *Result) Cases() []CaseResult {
+	return r.cases
+}
+
 // String returns a string representaton of the result for printing on the console.
 //
 // The format it prints will change and shouldn't be relied on for programmatic use.
@@ -194,10 +332,42 @@ func (r *Result) String() string {
 		fmt.Sprintf("Duration: %.1fs", r.elapsed.Seconds()),
 		fmt.Sprintf("Link: %s", link),
 	}
+	if r.aborted != nil {
+		lines = append(lines, fmt.Sprintf("Aborted: %v", r.aborted))
+	}
 	if linkErr != nil {
 		lines = append(lines, fmt.Sprintf("Warning: Failed to generate permalink: %v", linkErr))
 	}
 
+	if len(r.scores) > 0 {
+		lines = append(lines, "Scores:")
+		for _, s := range r.scores {
+			lines = append(lines, fmt.Sprintf("  %s: %.2f%% (n=%d, errors=%d)", s.Name, s.Mean*100, s.Count, s.Errors))
+		}
+	}
+
+	if c := r.comparison; c != nil {
+		lines = append(lines, fmt.Sprintf("Compared to %s:", c.BaseExperimentName))
+		for _, d := range c.Scores {
+			lines = append(lines, fmt.Sprintf("  %s: %+.2f%% (%d improvements, %d regressions)", d.Name, d.Delta*100, d.Improvements, d.Regressions))
+		}
+		if c.Skipped > 0 {
+			lines = append(lines, fmt.Sprintf("  %d cases were skipped because their input couldn't be encoded", c.Skipped))
+		}
+	}
+
+	if r.resumed > 0 {
+		lines = append(lines, fmt.Sprintf("Resumed: %d cases already logged were skipped", r.resumed))
+	}
+
+	if timeouts := r.Timeouts(); timeouts > 0 {
+		lines = append(lines, fmt.Sprintf("Timeouts: %d", timeouts))
+	}
+
+	if cost := r.Cost(); cost > 0 {
+		lines = append(lines, fmt.Sprintf("Cost: $%.4f", cost))
+	}
+
 	// Error details if present
 	if r.err != nil {
 		lines = append(lines, "Errors:")
@@ -234,12 +404,27 @@ type eval[I, R any] struct {
 	startSpanOpt   oteltrace.SpanStartOption
 	goroutines     int
 	quiet          bool
+	apiClient      *api.API
+	baseExperiment *experiments.Experiment // nil if not comparing
+	caseTimeout    time.Duration
+	scorerTimeout  time.Duration
+	retry          RetryPolicy
+	trials         int
+	errorBudget    ErrorBudget
+	progress       Progress       // nil if not reporting progress
+	scorerWorkers  int            // scorers run concurrently per case, 1 or less runs them in order
+	limiter        *Limiter       // nil if not limiting calls
+	resume         bool           // skip cases already logged to the experiment
+	caseID         func(I) string // nil to hash the input
 }
 
 // nextCase is a wrapper for sending cases through a channel.
 type nextCase[I, R any] struct {
 	c       Case[I, R]
 	iterErr error
+	index   int    // position in the dataset
+	trial   int    // zero-based trial index
+	caseID  string // empty for dataset cases, which are identified by record ID
 }
 
 // newEval creates a new eval executor from concrete parameters (low-level constructor).
@@ -297,8 +482,17 @@ func newEvalOpts[I, R any](ctx context.Context, s *auth.Session, tp *trace.Trace
 		projectName = project
 	}
 
+	baseExp, err := resolveBaseExperiment(ctx, apiClient, projectName, opts.BaseExperiment, opts.BaseExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	baseExpID := ""
+	if baseExp != nil {
+		baseExpID = baseExp.ID
+	}
+
 	// Register/get experiment (registerExperiment will validate that projectName is not empty)
-	exp, err := registerExperiment(ctx, apiClient, opts.Experiment, projectName, opts.Tags, opts.Metadata, opts.Update, opts.Dataset)
+	exp, err := registerExperiment(ctx, apiClient, opts.Experiment, projectName, opts.Tags, opts.Metadata, opts.Update || opts.Resume, opts.Dataset, baseExpID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register experiment: %w", err)
 	}
@@ -310,7 +504,7 @@ func newEvalOpts[I, R any](ctx context.Context, s *auth.Session, tp *trace.Trace
 	tracer := tp.Tracer("braintrust.eval")
 
 	// Call low-level newEval with concrete parameters
-	return newEval(
+	e := newEval(
 		s,
 		tracer,
 		exp.ID,
@@ -322,7 +516,23 @@ func newEvalOpts[I, R any](ctx context.Context, s *auth.Session, tp *trace.Trace
 		opts.Scorers,
 		opts.Parallelism,
 		opts.Quiet,
-	), nil
+	)
+	e.apiClient = apiClient
+	e.baseExperiment = baseExp
+	e.caseTimeout = opts.CaseTimeout
+	e.scorerTimeout = opts.ScorerTimeout
+	e.retry = opts.Retry
+	e.trials = opts.Trials
+	e.errorBudget = opts.ErrorBudget
+	e.progress = opts.Progress
+	e.scorerWorkers = opts.ScorerParallelism
+	e.limiter = opts.Limiter
+	e.resume = opts.Resume
+	e.caseID = opts.CaseID
+	if e.progress == nil && !opts.Quiet && isTerminal(os.Stderr) {
+		e.progress = NewProgressBar(os.Stderr)
+	}
+	return e, nil
 }
 
 func (e *eval[I, R]) run(ctx context.Context) (*Result, error) {
@@ -331,12 +541,44 @@ func (e *eval[I, R]) run(ctx context.Context) (*Result, error) {
 		return nil, fmt.Errorf("%w: experiment ID is required", errEval)
 	}
 
+	var logged loggedTrials
+	if e.resume {
+		var err error
+		logged, err = e.loadLoggedTrials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to fetch logged cases to resume: %w", errEval, err)
+		}
+	}
+
 	ctx = bttrace.SetParent(ctx, e.parent)
 
+	// runCtx is canceled when the error budget is exceeded, which stops in-flight cases.
+	runCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
 	// Scale buffer size with parallelism to avoid blocking, but cap at 100
 	bufferSize := minInt(e.goroutines*2, 100)
 	nextCases := make(chan nextCase[I, R], bufferSize)
 	var errs lockedErrors
+	var cases lockedCaseResults
+	budget := &budgetTracker{budget: e.errorBudget}
+
+	if e.progress != nil {
+		total := datasetLen(e.dataset)
+		if total > 0 {
+			// assumes the logged cases all come from this dataset
+			total = maxInt(total*maxInt(e.trials, 1)-logged.total(), 0)
+		}
+		e.progress.Started(total)
+	}
+
+	// notRun reports a case that was read from the dataset but not run because the eval was
+	// aborted, so it still counts towards the total.
+	notRun := func(index, trial int) {
+		if e.progress != nil {
+			e.progress.CaseFinished(CaseResult{Error: context.Cause(runCtx), Trial: trial, index: index})
+		}
+	}
 
 	// Spawn our goroutines to run the cases.
 	var wg sync.WaitGroup
@@ -349,27 +591,91 @@ func (e *eval[I, R]) run(ctx context.Context) (*Result, error) {
 				if !ok {
 					return
 				}
-				if err := e.runNextCase(ctx, nextCase); err != nil {
+				// drain the channel without running cases once aborted
+				if runCtx.Err() != nil {
+					notRun(nextCase.index, nextCase.trial)
+					continue
+				}
+				cr, err := e.runNextCase(runCtx, nextCase)
+				if err != nil {
 					errs.append(err)
 				}
+				if cr != nil {
+					cases.append(*cr)
+				}
+				if e.progress != nil {
+					if cr != nil {
+						e.progress.CaseFinished(*cr)
+					} else {
+						// the dataset failed to return the case, which still counts towards the total
+						e.progress.CaseFinished(CaseResult{Error: err, Trial: nextCase.trial, index: nextCase.index})
+					}
+				}
+				if abortErr := budget.record(err != nil); abortErr != nil {
+					abort(abortErr)
+				}
 			}
 		}()
 	}
 
-	// Fill our channel with the cases.
-	for {
+	// Fill our channel with the cases until the dataset is exhausted or we're aborted.
+	send := func(nc nextCase[I, R]) bool {
+		select {
+		case nextCases <- nc:
+			return true
+		case <-runCtx.Done():
+			return false
+		}
+	}
+	resumed := 0
+fill:
+	for index := 0; runCtx.Err() == nil; index++ {
 		c, err := e.dataset.Next()
 		if err == io.EOF {
-			close(nextCases)
 			break
 		}
-		nextCases <- nextCase[I, R]{c: c, iterErr: err}
+		if err != nil {
+			if !send(nextCase[I, R]{iterErr: err, index: index}) {
+				notRun(index, 0)
+				break
+			}
+			continue
+		}
+		caseID := caseIDOf(e.caseID, c)
+		trials := maxInt(e.trials, 1)
+		skip := logged.take(matchKey(c.ID, caseID), trials)
+		resumed += skip
+		for trial := skip; trial < trials; trial++ {
+			if !send(nextCase[I, R]{c: c, index: index, trial: trial, caseID: caseID}) {
+				for ; trial < trials; trial++ {
+					notRun(index, trial)
+				}
+				break fill
+			}
+		}
 	}
+	close(nextCases)
 
 	// Wait for all the goroutines to finish.
 	wg.Wait()
 	elapsed := time.Since(start)
 
+	aborted := context.Cause(runCtx)
+	if aborted != nil {
+		errs.append(aborted)
+	}
+
+	caseResults := cases.get()
+
+	var comparison *Comparison
+	if e.baseExperiment != nil {
+		var err error
+		comparison, err = e.compare(ctx, caseResults)
+		if err != nil {
+			errs.append(fmt.Errorf("%w: failed to compare with base experiment: %w", errEval, err))
+		}
+	}
+
 	err := errors.Join(errs.get()...)
 
 	permalink := e.permalink()
@@ -383,7 +689,15 @@ func (e *eval[I, R]) run(ctx context.Context) (*Result, error) {
 		err,
 		permalink,
 		elapsed,
+		caseResults,
 	)
+	result.comparison = comparison
+	result.aborted = aborted
+	result.resumed = resumed
+
+	if e.progress != nil {
+		e.progress.Finished(result)
+	}
 
 	// Print result summary unless quiet
 	if !e.quiet {
@@ -394,8 +708,8 @@ func (e *eval[I, R]) run(ctx context.Context) (*Result, error) {
 }
 
 // runNextCase handles a single case from the channel.
-// Copied from old package.
-func (e *eval[I, R]) runNextCase(ctx context.Context, nextCase nextCase[I, R]) error {
+// It returns a nil CaseResult if the case iterator returned an error.
+func (e *eval[I, R]) runNextCase(ctx context.Context, nextCase nextCase[I, R]) (*CaseResult, error) {
 	// if we have a case or get an error, we'll create a span.
 	ctx, span := e.tracer.Start(ctx, "eval", e.startSpanOpt)
 	defer span.End()
@@ -405,63 +719,137 @@ func (e *eval[I, R]) runNextCase(ctx context.Context, nextCase nextCase[I, R]) e
 	if nextCase.iterErr != nil {
 		werr := fmt.Errorf("%w: %w", errCaseIterator, nextCase.iterErr)
 		recordSpanError(span, werr)
-		return werr
+		return nil, werr
+	}
+
+	ctx, cancel := withTimeout(ctx, e.caseTimeout, "case")
+	defer cancel()
+
+	if e.progress != nil {
+		e.progress.CaseStarted(nextCase.index, nextCase.trial)
 	}
 
 	// otherwise let's run the case (using the existing span)
-	return e.runCase(ctx, span, nextCase.c)
+	cr := CaseResult{
+		Input:    nextCase.c.Input,
+		Expected: nextCase.c.Expected,
+		Tags:     nextCase.c.Tags,
+		Metadata: nextCase.c.Metadata,
+		ID:       nextCase.c.ID,
+		Trial:    nextCase.trial,
+		index:    nextCase.index,
+		key:      matchKey(nextCase.c.ID, nextCase.caseID),
+	}
+	ctx, costs := pricing.WithTracker(ctx)
+	err := e.runCase(ctx, span, nextCase.c, nextCase.trial, nextCase.caseID, &cr)
+	cr.Cost = costs.Total()
+	cr.Error = err
+	cr.TimedOut = errors.Is(err, errTimeout)
+	return &cr, err
 }
 
-// runCase orchestrates task + scorers for one case.
-// Copied from old package.
-func (e *eval[I, R]) runCase(ctx context.Context, span oteltrace.Span, c Case[I, R]) error {
-	if c.Tags != nil {
-		span.SetAttributes(attribute.StringSlice("braintrust.tags", c.Tags))
+// caseIDOf returns the case ID of c given by caseID, or the hash of its input if caseID is nil.
+// It returns an empty string if c is from a Braintrust dataset.
+func caseIDOf[I, R any](caseID func(input I) string, c Case[I, R]) string {
+	if c.ID != "" {
+		return ""
 	}
-
-	taskResult, err := e.runTask(ctx, span, c)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		return err
+	if caseID != nil {
+		return caseID(c.Input)
 	}
-	output := taskResult.Output
+	// an input that can't be hashed can't be encoded on the eval span either, which
+	// is reported when the case runs
+	hash, _ := inputHash(c.Input)
+	return hash
+}
 
-	_, err = e.runScorers(ctx, taskResult)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		return err
+// runCase orchestrates task + scorers for one trial of a case, recording the outcome in cr.
+func (e *eval[I, R]) runCase(ctx context.Context, span oteltrace.Span, c Case[I, R], trial int, caseID string, cr *CaseResult) error {
+	if c.Tags != nil {
+		span.SetAttributes(attribute.StringSlice("braintrust.tags", c.Tags))
 	}
 
+	// The input and metadata are set before running the task, so a resumed eval can
+	// match the case even if the task or a scorer fails.
 	meta := map[string]any{
 		"braintrust.span_attributes": evalSpanAttrs,
 		"braintrust.input_json":      c.Input,
-		"braintrust.output_json":     output,
 		"braintrust.expected":        c.Expected,
 	}
 
-	// Add case metadata if present
-	if c.Metadata != nil {
+	// Add case metadata if present, tagging each trial with its index when running trials
+	// and cases that aren't from a dataset with their case ID
+	if e.trials > 1 || caseID != "" {
+		caseMeta := make(map[string]any, len(c.Metadata)+2)
+		for k, v := range c.Metadata {
+			caseMeta[k] = v
+		}
+		if e.trials > 1 {
+			caseMeta["trial_index"] = trial
+		}
+		if caseID != "" {
+			caseMeta["case_id"] = caseID
+		}
+		meta["braintrust.metadata"] = caseMeta
+	} else if c.Metadata != nil {
 		meta["braintrust.metadata"] = c.Metadata
 	}
 
 	// Add origin if this case came from a dataset
 	// Origin links the eval result back to the source dataset row
 	if c.ID != "" && c.XactID != "" {
+		datasetID := e.datasetID
+		if c.datasetID != "" {
+			datasetID = c.datasetID
+		}
 		meta["braintrust.origin"] = map[string]any{
 			"object_type": "dataset",
-			"object_id":   e.datasetID,
+			"object_id":   datasetID,
 			"id":          c.ID,
 			"created":     c.Created,
 			"_xact_id":    c.XactID,
 		}
 	}
 
-	return setJSONAttrs(span, meta)
+	metaErr := setJSONAttrs(span, meta)
+
+	taskResult, err := e.runTask(ctx, span, c, trial)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		// the case is missing every score
+		for _, scorer := range e.scorers {
+			cr.unscored = append(cr.unscored, scorer.Name())
+		}
+		return errors.Join(err, metaErr)
+	}
+	cr.Output = taskResult.Output
+	metaErr = errors.Join(metaErr, setJSONAttr(span, "braintrust.output_json", taskResult.Output))
+
+	scores, outcomes, err := e.runScorers(ctx, taskResult)
+	cr.scorerScores = outcomes.produced
+	cr.failedScorers = outcomes.failed
+	if len(scores) > 0 {
+		cr.Scores = make(map[string]float64, len(scores))
+		for _, score := range scores {
+			if !score.Skipped {
+				cr.Scores[score.Name] = score.Score
+			}
+			if e.progress != nil {
+				e.progress.Score(cr.index, cr.Trial, score)
+			}
+		}
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return errors.Join(err, metaErr)
+	}
+
+	return metaErr
 }
 
 // runTask executes the task function and creates a task span.
 // Returns a TaskResult containing all task execution data.
-func (e *eval[I, R]) runTask(ctx context.Context, evalSpan oteltrace.Span, c Case[I, R]) (TaskResult[I, R], error) {
+func (e *eval[I, R]) runTask(ctx context.Context, evalSpan oteltrace.Span, c Case[I, R], trial int) (TaskResult[I, R], error) {
 	ctx, taskSpan := e.tracer.Start(ctx, "task", e.startSpanOpt)
 	defer taskSpan.End()
 
@@ -480,15 +868,29 @@ func (e *eval[I, R]) runTask(ctx context.Context, evalSpan oteltrace.Span, c Cas
 
 	// Construct TaskHooks with both spans and case data
 	hooks := &TaskHooks{
-		Expected: c.Expected,
-		Metadata: c.Metadata,
-		Tags:     c.Tags,
-		TaskSpan: taskSpan,
-		EvalSpan: evalSpan,
+		Expected:   c.Expected,
+		Metadata:   c.Metadata,
+		Tags:       c.Tags,
+		TaskSpan:   taskSpan,
+		EvalSpan:   evalSpan,
+		TrialIndex: trial,
 	}
 
-	// Call task with new signature
-	taskOutput, err := e.task(ctx, c.Input, hooks)
+	// Call task with new signature, retrying failures if configured.
+	// This returns early if the case times out.
+	var waited atomic.Int64
+	taskOutput, err := callWithRetry(ctx, e.retry, taskSpan, func() (TaskOutput[R], error) {
+		release, w, err := e.limiter.acquire(ctx)
+		waited.Add(int64(w))
+		if err != nil {
+			return TaskOutput[R]{}, err
+		}
+		defer release()
+		return e.task(ctx, c.Input, hooks)
+	})
+	if e.limiter != nil {
+		recordLimiterWait(taskSpan, time.Duration(waited.Load()))
+	}
 	if err != nil {
 		// if the task fails, don't worry about the encode errors....
 		taskErr := fmt.Errorf("%w: %w", errTaskRun, err)
@@ -516,41 +918,51 @@ func (e *eval[I, R]) runTask(ctx context.Context, evalSpan oteltrace.Span, c Cas
 }
 
 // runScorers executes all scorers and creates a score span.
-func (e *eval[I, R]) runScorers(ctx context.Context, taskResult TaskResult[I, R]) ([]Score, error) {
+// It returns the successful scores, and which scorers produced them or failed.
+func (e *eval[I, R]) runScorers(ctx context.Context, taskResult TaskResult[I, R]) ([]Score, scorerOutcomes, error) {
 	ctx, span := e.tracer.Start(ctx, "score", e.startSpanOpt)
 	defer span.End()
 
 	if err := setJSONAttr(span, "braintrust.span_attributes", scoreSpanAttrs); err != nil {
-		return nil, err
+		return nil, scorerOutcomes{}, err
 	}
 
+	ctx, limiterState := withLimiter(ctx, e.limiter)
+	results := e.runAllScorers(ctx, taskResult)
+	limiterState.record(span)
+
 	var scores []Score
+	outcomes := scorerOutcomes{produced: make(map[string][]string, len(results))}
 
 	var errs []error
-	for _, scorer := range e.scorers {
-		curScores, err := scorer.Run(ctx, taskResult)
-		if err != nil {
-			werr := fmt.Errorf("%w: scorer %q failed: %w", errScorer, scorer.Name(), err)
+	for i, r := range results {
+		scorer := e.scorers[i]
+		if r.err != nil {
+			werr := fmt.Errorf("%w: scorer %q failed: %w", errScorer, scorer.Name(), r.err)
 			recordSpanError(span, werr)
 			errs = append(errs, werr)
+			outcomes.failed = append(outcomes.failed, scorer.Name())
 			continue
 		}
-		for _, score := range curScores {
+		names := make([]string, 0, len(r.scores))
+		for _, score := range r.scores {
 			if score.Name == "" {
 				score.Name = scorer.Name()
 			}
 			scores = append(scores, score)
+			names = append(names, score.Name)
 		}
+		outcomes.produced[scorer.Name()] = names
 	}
 
-	// Build scores map (name -> score value)
-	valsByName := make(map[string]float64, len(scores))
+	// Build scores map (name -> score value, nil for skipped scores)
+	valsByName := make(map[string]*float64, len(scores))
 	for _, score := range scores {
-		valsByName[score.Name] = score.Score
+		valsByName[score.Name] = score.value()
 	}
 
 	if err := setJSONAttr(span, "braintrust.scores", valsByName); err != nil {
-		return nil, err
+		return nil, outcomes, err
 	}
 
 	// Build metadata and output following Python/TypeScript conventions
@@ -562,7 +974,7 @@ func (e *eval[I, R]) runScorers(ctx context.Context, taskResult TaskResult[I, R]
 		if score.Metadata != nil {
 			metadata[score.Name] = score.Metadata
 		}
-		output[score.Name] = map[string]any{"score": score.Score}
+		output[score.Name] = map[string]any{"score": score.value()}
 	}
 
 	// For single score: flatten metadata and output to top level
@@ -570,28 +982,108 @@ func (e *eval[I, R]) runScorers(ctx context.Context, taskResult TaskResult[I, R]
 		score := scores[0]
 		if score.Metadata != nil {
 			if err := setJSONAttr(span, "braintrust.metadata", score.Metadata); err != nil {
-				return nil, err
+				return nil, outcomes, err
 			}
 		}
-		if err := setJSONAttr(span, "braintrust.output", map[string]any{"score": score.Score}); err != nil {
-			return nil, err
+		if err := setJSONAttr(span, "braintrust.output", map[string]any{"score": score.value()}); err != nil {
+			return nil, outcomes, err
 		}
 	} else if len(scores) > 1 {
 		// Multiple scores: use nested structure
 		if len(metadata) > 0 {
 			if err := setJSONAttr(span, "braintrust.metadata", metadata); err != nil {
-				return nil, err
+				return nil, outcomes, err
 			}
 		}
 		if err := setJSONAttr(span, "braintrust.output", output); err != nil {
-			return nil, err
+			return nil, outcomes, err
 		}
 	}
 
 	err := errors.Join(errs...) // will be nil if there are no errors
+	return scores, outcomes, err
+}
+
+// scorerOutcomes records the names of the scores each scorer produced for a case, and the
+// names of the scorers that failed.
+type scorerOutcomes struct {
+	produced map[string][]string
+	failed   []string
+}
+
+// scorerResult is the outcome of running a single scorer.
+type scorerResult struct {
+	scores Scores
+	err    error
+}
+
+// runAllScorers runs every scorer and returns their results in the order of e.scorers.
+// Scorers run one after another unless scorerWorkers is greater than 1, in which case
+// up to scorerWorkers run at once, each in its own span.
+func (e *eval[I, R]) runAllScorers(ctx context.Context, taskResult TaskResult[I, R]) []scorerResult {
+	results := make([]scorerResult, len(e.scorers))
+	if e.scorerWorkers <= 1 || len(e.scorers) <= 1 {
+		for i, scorer := range e.scorers {
+			results[i].scores, results[i].err = e.runScorer(ctx, scorer, taskResult)
+		}
+		return results
+	}
+
+	sem := make(chan struct{}, e.scorerWorkers)
+	var wg sync.WaitGroup
+	for i, scorer := range e.scorers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].scores, results[i].err = e.runScorerSpan(ctx, scorer, taskResult)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runScorerSpan runs a single scorer in a child span named after the scorer.
+func (e *eval[I, R]) runScorerSpan(ctx context.Context, scorer Scorer[I, R], taskResult TaskResult[I, R]) (Scores, error) {
+	ctx, span := e.tracer.Start(ctx, scorer.Name(), e.startSpanOpt)
+	defer span.End()
+
+	if err := setJSONAttr(span, "braintrust.span_attributes", scorerSpanAttrs(scorer.Name())); err != nil {
+		return nil, err
+	}
+	// record this scorer's wait on its own span, and add it to the score span's total
+	parent, _ := ctx.Value(limiterKey{}).(*limiterState)
+	ctx, limiterState := withLimiter(ctx, e.limiter)
+	scores, err := e.runScorer(ctx, scorer, taskResult)
+	if limiterState != nil {
+		limiterState.record(span)
+		parent.waited.Add(limiterState.waited.Load())
+	}
+	if err != nil {
+		recordSpanError(span, fmt.Errorf("%w: %w", errScorer, err))
+	}
 	return scores, err
 }
 
+// runScorer runs a single scorer, bounded by the scorer timeout.
+func (e *eval[I, R]) runScorer(ctx context.Context, scorer Scorer[I, R], taskResult TaskResult[I, R]) (Scores, error) {
+	ctx, cancel := withTimeout(ctx, e.scorerTimeout, fmt.Sprintf("scorer %q", scorer.Name()))
+	defer cancel()
+	return callWithContext(ctx, func() (Scores, error) {
+		return scorer.Run(ctx, taskResult)
+	})
+}
+
+// compare compares the eval's cases to the cases logged to the base experiment.
+func (e *eval[I, R]) compare(ctx context.Context, cases []CaseResult) (*Comparison, error) {
+	baseCases, err := fetchLoggedCases(ctx, e.apiClient.Experiments(), e.baseExperiment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return compareCases(e.baseExperiment, cases, baseCases), nil
+}
+
 // permalink generates a URL to view the eval in Braintrust UI.
 func (e *eval[I, R]) permalink() string {
 	appURL := e.session.AppPublicURL()
@@ -660,6 +1152,10 @@ func recordSpanError(span oteltrace.Span, err error) {
 	// showing the actual error type in the braintrust ui.
 	var errType string
 	switch {
+	case errors.Is(err, errTimeout):
+		errType = "ErrTimeout"
+	case errors.Is(err, errAborted):
+		errType = "ErrAborted"
 	case errors.Is(err, errScorer):
 		errType = "ErrScorer"
 	case errors.Is(err, errTaskRun):
@@ -697,6 +1193,14 @@ func (e *lockedErrors) get() []error {
 	return e.errs
 }
 
+// maxInt returns the maximum of two integers
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // minInt returns the minimum of two integers (Go 1.21+ has this in stdlib)
 func minInt(a, b int) int {
 	if a < b {
@@ -14,6 +14,7 @@ import (
 	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
 	"github.com/braintrustdata/braintrust-sdk-go/internal/tests"
 	"github.com/braintrustdata/braintrust-sdk-go/trace"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/pricing"
 )
 
 // testInput and testOutput are simple types for testing
@@ -136,6 +137,12 @@ func TestNewEval_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 
+	// In-memory cases are identified by a hash of their input
+	caseID1, err := inputHash(testInput{Value: "test1"})
+	require.NoError(t, err)
+	caseID2, err := inputHash(testInput{Value: "test2"})
+	require.NoError(t, err)
+
 	// Verify all spans were created with correct structure
 	// Spans are in completion order: task, score, eval for each case
 	spans := ute.exporter.Flush()
@@ -178,12 +185,12 @@ func TestNewEval_Success(t *testing.T) {
 			"braintrust.input_json":      map[string]any{"value": "test1"},
 			"braintrust.output_json":     map[string]any{"result": "output-test1"},
 			"braintrust.expected":        map[string]any{"result": "expected1"},
-			"braintrust.metadata":        map[string]any{"key": "value"},
+			"braintrust.metadata":        map[string]any{"key": "value", "case_id": caseID1},
 			"braintrust.span_attributes": map[string]any{"type": "eval"},
 		},
 	})
 
-	// Second case spans (no tags or metadata)
+	// Second case spans (no tags, and only the case ID in metadata)
 	spans[3].AssertEqual(oteltest.TestSpan{
 		Name: "task",
 		Attrs: map[string]any{
@@ -219,6 +226,7 @@ func TestNewEval_Success(t *testing.T) {
 			"braintrust.input_json":      map[string]any{"value": "test2"},
 			"braintrust.output_json":     map[string]any{"result": "output-test2"},
 			"braintrust.expected":        map[string]any{"result": "expected2"},
+			"braintrust.metadata":        map[string]any{"case_id": caseID2},
 			"braintrust.span_attributes": map[string]any{"type": "eval"},
 		},
 	})
@@ -985,6 +993,76 @@ func TestEval_ScoreMetadata_NoMetadata(t *testing.T) {
 	assert.False(t, scoreSpan.HasAttr("braintrust.metadata"), "braintrust.metadata should not be present")
 }
 
+func TestEval_SkippedScores(t *testing.T) {
+	t.Parallel()
+
+	// Skipped scores are logged as null and left out of the summary
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "b"}},
+	})
+
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	scorers := []Scorer[testInput, testOutput]{
+		NewScorer("only_a", func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+			if result.Input.Value != "a" {
+				return Skip(), nil
+			}
+			return S(0.5), nil
+		}),
+		NewScorer("always", func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+			return S(1), nil
+		}),
+	}
+
+	ute := newUnitTestEval(t, cases, task, scorers, 1)
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+
+	summary, ok := result.Score("only_a")
+	require.True(t, ok)
+	assert.Equal(t, 1, summary.Count)
+	assert.Equal(t, 0.5, summary.Mean)
+	assert.Equal(t, map[string]float64{"always": 1}, result.Cases()[1].Scores)
+
+	var scoreAttrs, outputAttrs []string
+	for _, span := range ute.exporter.Flush() {
+		if span.Name() == "score" {
+			scoreAttrs = append(scoreAttrs, span.Attr("braintrust.scores").String())
+			outputAttrs = append(outputAttrs, span.Attr("braintrust.output").String())
+		}
+	}
+	assert.ElementsMatch(t, []string{`{"always":1,"only_a":0.5}`, `{"always":1,"only_a":null}`}, scoreAttrs)
+	assert.Contains(t, outputAttrs, `{"always":{"score":1},"only_a":{"score":null}}`)
+}
+
+func TestEval_SkippedScore_SingleScorer(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+	scorer := NewScorer("not_applicable", func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+		return Skip(), nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{scorer}, 1)
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Scores())
+
+	spans := ute.exporter.Flush()
+	require.Len(t, spans, 3)
+	scoreSpan := spans[1]
+	scoreSpan.AssertNameIs("score")
+	scoreSpan.AssertJSONAttrEquals("braintrust.scores", map[string]any{"not_applicable": nil})
+	scoreSpan.AssertJSONAttrEquals("braintrust.output", map[string]any{"score": nil})
+}
+
 // TestCase_DatasetFields tests that the Case struct can hold dataset-specific fields
 func TestCase_DatasetFields(t *testing.T) {
 	// Create a case with dataset fields populated
@@ -1203,3 +1281,35 @@ func TestTaskOutput_UserData(t *testing.T) {
 		}
 	}
 }
+
+func TestEval_Cost(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "b"}},
+	})
+	// the traced LLM clients track the cost of each call in the context
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		pricing.Track(ctx, 0.25)
+		if input.Value == "b" {
+			pricing.Track(ctx, 0.5)
+		}
+		return testOutput{Result: input.Value}, nil
+	})
+	judge := NewScorer("judge", func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+		pricing.Track(ctx, 0.125)
+		return S(1), nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{judge}, 2)
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+
+	rows := result.Cases()
+	require.Len(t, rows, 2)
+	assert.Equal(t, 0.375, rows[0].Cost)
+	assert.Equal(t, 0.875, rows[1].Cost)
+	assert.Equal(t, 1.25, result.Cost())
+	assert.Contains(t, result.String(), "Cost: $1.2500")
+}
@@ -44,7 +44,8 @@ func (e *Evaluator[I, R]) Functions() *FunctionsAPI[I, R] {
 // Datasets is used to access Datasets API for loading datasets with this evaluator's type parameters.
 func (e *Evaluator[I, R]) Datasets() *DatasetAPI[I, R] {
 	return &DatasetAPI[I, R]{
-		api: e.api,
+		api:         e.api,
+		projectName: e.defaultProjectName,
 	}
 }
 
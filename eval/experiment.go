@@ -18,7 +18,8 @@ type datasetInfo interface {
 // registerExperiment creates or gets an experiment for the eval.
 // This is an internal helper that uses the api package.
 // projectName must be already resolved (not empty) by the caller.
-func registerExperiment(ctx context.Context, apiClient *api.API, name string, projectName string, tags []string, metadata map[string]interface{}, update bool, dataset datasetInfo) (*experiments.Experiment, error) {
+// baseExpID is optional and links the experiment to a base experiment for comparison.
+func registerExperiment(ctx context.Context, apiClient *api.API, name string, projectName string, tags []string, metadata map[string]interface{}, update bool, dataset datasetInfo, baseExpID string) (*experiments.Experiment, error) {
 	if name == "" {
 		return nil, fmt.Errorf("experiment name is required")
 	}
@@ -47,6 +48,7 @@ func registerExperiment(ctx context.Context, apiClient *api.API, name string, pr
 		Update:         update,
 		DatasetID:      datasetID,
 		DatasetVersion: datasetVersion,
+		BaseExpID:      baseExpID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to register experiment: %w", err)
@@ -54,3 +56,38 @@ func registerExperiment(ctx context.Context, apiClient *api.API, name string, pr
 
 	return experiment, nil
 }
+
+// resolveBaseExperiment looks up the experiment to compare an eval against.
+// If id is set it is used directly, otherwise the most recent experiment named name in
+// the project is used. It returns nil if neither is set.
+func resolveBaseExperiment(ctx context.Context, apiClient *api.API, projectName string, name string, id string) (*experiments.Experiment, error) {
+	if id != "" {
+		experiment, err := apiClient.Experiments().Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base experiment %q: %w", id, err)
+		}
+		return experiment, nil
+	}
+
+	if name == "" {
+		return nil, nil
+	}
+
+	if projectName == "" {
+		return nil, fmt.Errorf("project name is required (set via WithProject option or Opts.ProjectName)")
+	}
+
+	response, err := apiClient.Experiments().List(ctx, experiments.ListParams{
+		ProjectName:    projectName,
+		ExperimentName: name,
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find base experiment %q: %w", name, err)
+	}
+	if len(response.Objects) == 0 {
+		return nil, fmt.Errorf("base experiment %q not found in project %q", name, projectName)
+	}
+
+	return &response.Objects[0], nil
+}
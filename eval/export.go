@@ -0,0 +1,326 @@
+package eval
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JUnitOpts configures [Result.WriteJUnit].
+type JUnitOpts struct {
+	// Thresholds maps score names to the minimum passing value. A case fails if any of its
+	// scores is below its threshold. Scores without a threshold never fail a case.
+	Thresholds map[string]float64
+
+	// CaseName returns the name of the testcase for a case. (default: the dataset record ID,
+	// or the case's position in the dataset and its input)
+	CaseName func(CaseResult) string
+}
+
+// maxJUnitNameLen is the length at which default testcase names are truncated.
+const maxJUnitNameLen = 80
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+	SystemErr  string          `xml:"system-err,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitMessage   `xml:"failure,omitempty"`
+	Error      *junitMessage   `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the result to w as JUnit XML, so CI systems can report eval outcomes
+// like test results. The eval is a single testsuite named after the experiment, with one
+// testcase per case (and trial). Cases whose task or scorers returned an error are reported
+// as errors, and cases with a score below its threshold in opts are reported as failures.
+// Each testcase records its scores as properties.
+func (r *Result) WriteJUnit(w io.Writer, opts JUnitOpts) error {
+	suite := junitTestSuite{
+		Name:  r.key.name,
+		Tests: len(r.cases),
+		Time:  formatSeconds(r.elapsed.Seconds()),
+		Properties: []junitProperty{
+			{Name: "experiment_id", Value: r.key.experimentID},
+			{Name: "project", Value: r.key.projectName},
+			{Name: "permalink", Value: r.permalink},
+		},
+	}
+	if r.err != nil {
+		suite.SystemErr = r.err.Error()
+	}
+
+	for _, c := range r.cases {
+		name := defaultJUnitCaseName(c)
+		if opts.CaseName != nil {
+			name = opts.CaseName(c)
+		}
+		tc := junitTestCase{Name: name, Classname: r.key.name}
+
+		for _, scoreName := range sortedScoreNames(c.Scores) {
+			tc.Properties = append(tc.Properties, junitProperty{
+				Name:  "score." + scoreName,
+				Value: strconv.FormatFloat(c.Scores[scoreName], 'f', -1, 64),
+			})
+		}
+
+		if c.Error != nil {
+			errType := "error"
+			if c.TimedOut {
+				errType = "timeout"
+			}
+			tc.Error = &junitMessage{Message: c.Error.Error(), Type: errType, Text: c.Error.Error()}
+			suite.Errors++
+		} else if failed := belowThreshold(c.Scores, opts.Thresholds); len(failed) > 0 {
+			tc.Failure = &junitMessage{
+				Message: strings.Join(failed, "; "),
+				Type:    "threshold",
+				Text:    strings.Join(failed, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	doc := junitTestSuites{
+		Name:     r.key.name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JUnit XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// defaultJUnitCaseName names a case by its dataset record ID, or by its position in the
+// dataset and its input, truncated to maxJUnitNameLen.
+func defaultJUnitCaseName(c CaseResult) string {
+	name := c.ID
+	if name == "" {
+		name = fmt.Sprintf("case %d", c.index)
+		if input, err := json.Marshal(c.Input); err == nil {
+			name += ": " + string(input)
+		}
+	}
+	if c.Trial > 0 {
+		name = fmt.Sprintf("%s (trial %d)", name, c.Trial)
+	}
+	if runes := []rune(name); len(runes) > maxJUnitNameLen {
+		name = string(runes[:maxJUnitNameLen-3]) + "..."
+	}
+	return name
+}
+
+// belowThreshold returns a description of each score that is below its threshold, sorted by name.
+func belowThreshold(scores map[string]float64, thresholds map[string]float64) []string {
+	var failed []string
+	for _, name := range sortedScoreNames(scores) {
+		threshold, ok := thresholds[name]
+		if ok && scores[name] < threshold {
+			failed = append(failed, fmt.Sprintf("%s: %g is below threshold %g", name, scores[name], threshold))
+		}
+	}
+	return failed
+}
+
+func sortedScoreNames(scores map[string]float64) []string {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatSeconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', 3, 64)
+}
+
+// resultJSONVersion is the version of the format written by [Result.WriteJSON]. It only
+// changes if fields are removed or change meaning; new fields may be added at any time.
+const resultJSONVersion = 1
+
+type resultJSON struct {
+	Version         int                `json:"version"`
+	ExperimentID    string             `json:"experiment_id"`
+	ExperimentName  string             `json:"experiment_name"`
+	ProjectID       string             `json:"project_id"`
+	ProjectName     string             `json:"project_name"`
+	Permalink       string             `json:"permalink"`
+	DurationSeconds float64            `json:"duration_seconds"`
+	Error           *string            `json:"error"`
+	Aborted         bool               `json:"aborted"`
+	Resumed         int                `json:"resumed"`
+	Timeouts        int                `json:"timeouts"`
+	Cost            float64            `json:"cost"`
+	Scores          []scoreSummaryJSON `json:"scores"`
+	Comparison      *comparisonJSON    `json:"comparison"`
+	Cases           []caseResultJSON   `json:"cases"`
+}
+
+type scoreSummaryJSON struct {
+	Name   string  `json:"name"`
+	Mean   float64 `json:"mean"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Count  int     `json:"count"`
+	Errors int     `json:"errors"`
+}
+
+type comparisonJSON struct {
+	BaseExperimentID   string           `json:"base_experiment_id"`
+	BaseExperimentName string           `json:"base_experiment_name"`
+	Scores             []scoreDeltaJSON `json:"scores"`
+	Improved           int              `json:"improved"`
+	Regressed          int              `json:"regressed"`
+	Skipped            int              `json:"skipped"`
+}
+
+type scoreDeltaJSON struct {
+	Name         string  `json:"name"`
+	Mean         float64 `json:"mean"`
+	BaseMean     float64 `json:"base_mean"`
+	Delta        float64 `json:"delta"`
+	Improvements int     `json:"improvements"`
+	Regressions  int     `json:"regressions"`
+}
+
+type caseResultJSON struct {
+	Index    int                `json:"index"`
+	Trial    int                `json:"trial"`
+	ID       string             `json:"id,omitempty"`
+	Input    any                `json:"input"`
+	Expected any                `json:"expected"`
+	Output   any                `json:"output"`
+	Tags     []string           `json:"tags,omitempty"`
+	Metadata Metadata           `json:"metadata,omitempty"`
+	Scores   map[string]float64 `json:"scores"`
+	Error    *string            `json:"error"`
+	TimedOut bool               `json:"timed_out"`
+	Cost     float64            `json:"cost"`
+}
+
+// WriteJSON writes a machine-readable summary of the result to w. Unlike [Result.String],
+// the format is stable: a "version" field identifies it, and existing fields keep their
+// names and meaning. It contains the experiment, the eval's error (or null), its estimated
+// cost, the score summaries, the comparison with the base experiment (or null), and every
+// case with its input, expected value, output, scores, error and cost.
+func (r *Result) WriteJSON(w io.Writer) error {
+	out := resultJSON{
+		Version:         resultJSONVersion,
+		ExperimentID:    r.key.experimentID,
+		ExperimentName:  r.key.name,
+		ProjectID:       r.key.projectID,
+		ProjectName:     r.key.projectName,
+		Permalink:       r.permalink,
+		DurationSeconds: r.elapsed.Seconds(),
+		Error:           errorString(r.err),
+		Aborted:         r.Aborted(),
+		Resumed:         r.resumed,
+		Timeouts:        r.Timeouts(),
+		Cost:            r.Cost(),
+		Scores:          []scoreSummaryJSON{},
+		Cases:           []caseResultJSON{},
+	}
+
+	for _, s := range r.scores {
+		out.Scores = append(out.Scores, scoreSummaryJSON(s))
+	}
+
+	if c := r.comparison; c != nil {
+		cmp := &comparisonJSON{
+			BaseExperimentID:   c.BaseExperimentID,
+			BaseExperimentName: c.BaseExperimentName,
+			Scores:             []scoreDeltaJSON{},
+			Improved:           len(c.Improved),
+			Regressed:          len(c.Regressed),
+			Skipped:            c.Skipped,
+		}
+		for _, d := range c.Scores {
+			cmp.Scores = append(cmp.Scores, scoreDeltaJSON(d))
+		}
+		out.Comparison = cmp
+	}
+
+	for _, c := range r.cases {
+		scores := c.Scores
+		if scores == nil {
+			scores = map[string]float64{}
+		}
+		out.Cases = append(out.Cases, caseResultJSON{
+			Index:    c.index,
+			Trial:    c.Trial,
+			ID:       c.ID,
+			Input:    c.Input,
+			Expected: c.Expected,
+			Output:   c.Output,
+			Tags:     c.Tags,
+			Metadata: c.Metadata,
+			Scores:   scores,
+			Error:    errorString(c.Error),
+			TimedOut: c.TimedOut,
+			Cost:     c.Cost,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode result JSON: %w", err)
+	}
+	return nil
+}
+
+// errorString returns err's message, or nil if err is nil.
+func errorString(err error) *string {
+	if err == nil {
+		return nil
+	}
+	s := err.Error()
+	return &s
+}
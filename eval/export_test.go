@@ -0,0 +1,162 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExportResult returns a result with a passing case, a low-scoring case, a failed case
+// and a dataset case with a second trial.
+func newExportResult() *Result {
+	cases := []CaseResult{
+		{Input: "a", Output: "a", Scores: map[string]float64{"accuracy": 1, "fluency": 0.9}, Cost: 0.5, index: 0},
+		{Input: "b", Output: "x", Scores: map[string]float64{"accuracy": 0.2, "fluency": 0.9}, Cost: 0.25, index: 1},
+		{Input: "c", Error: fmt.Errorf("%w: deadline", errTimeout), TimedOut: true, index: 2},
+		{Input: "d", ID: "row-d", Scores: map[string]float64{"accuracy": 1}, Trial: 1, index: 3},
+	}
+	k := key{experimentID: "exp-1", name: "my-experiment", projectID: "proj-1", projectName: "my-project"}
+	return newResult(k, errors.New("1 case failed"), "https://example.com/exp-1", 1500*time.Millisecond, cases)
+}
+
+func TestResult_WriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := newExportResult().WriteJUnit(&buf, JUnitOpts{Thresholds: map[string]float64{"accuracy": 0.5}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var doc junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, 4, doc.Tests)
+	assert.Equal(t, 1, doc.Failures)
+	assert.Equal(t, 1, doc.Errors)
+	assert.Equal(t, "1.500", doc.Time)
+	require.Len(t, doc.Suites, 1)
+
+	suite := doc.Suites[0]
+	assert.Equal(t, "my-experiment", suite.Name)
+	assert.Equal(t, "1 case failed", suite.SystemErr)
+	assert.Contains(t, suite.Properties, junitProperty{Name: "permalink", Value: "https://example.com/exp-1"})
+	require.Len(t, suite.Cases, 4)
+
+	passed := suite.Cases[0]
+	assert.Equal(t, `case 0: "a"`, passed.Name)
+	assert.Equal(t, "my-experiment", passed.Classname)
+	assert.Nil(t, passed.Failure)
+	assert.Nil(t, passed.Error)
+	assert.Equal(t, []junitProperty{{Name: "score.accuracy", Value: "1"}, {Name: "score.fluency", Value: "0.9"}}, passed.Properties)
+
+	low := suite.Cases[1]
+	require.NotNil(t, low.Failure)
+	assert.Equal(t, "threshold", low.Failure.Type)
+	assert.Equal(t, "accuracy: 0.2 is below threshold 0.5", low.Failure.Message)
+
+	failed := suite.Cases[2]
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, "timeout", failed.Error.Type)
+	assert.Contains(t, failed.Error.Message, "deadline")
+
+	assert.Equal(t, "row-d (trial 1)", suite.Cases[3].Name)
+}
+
+func TestResult_WriteJUnit_CaseName(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := newExportResult().WriteJUnit(&buf, JUnitOpts{CaseName: func(c CaseResult) string {
+		return "input " + c.Input.(string)
+	}})
+	require.NoError(t, err)
+
+	var doc junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "input a", doc.Suites[0].Cases[0].Name)
+	assert.Equal(t, 0, doc.Failures, "no thresholds, no failures")
+
+	long := defaultJUnitCaseName(CaseResult{Input: strings.Repeat("é", 100)})
+	assert.Len(t, []rune(long), maxJUnitNameLen)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
+
+func TestResult_WriteJSON(t *testing.T) {
+	t.Parallel()
+
+	result := newExportResult()
+	result.comparison = &Comparison{
+		BaseExperimentID:   "exp-0",
+		BaseExperimentName: "base",
+		Scores:             []ScoreDelta{{Name: "accuracy", Mean: 0.73, BaseMean: 0.5, Delta: 0.23, Improvements: 2}},
+		Improved:           []CaseDelta{{}, {}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteJSON(&buf))
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, 1.0, out["version"])
+	assert.Equal(t, "exp-1", out["experiment_id"])
+	assert.Equal(t, "my-experiment", out["experiment_name"])
+	assert.Equal(t, "my-project", out["project_name"])
+	assert.Equal(t, 1.5, out["duration_seconds"])
+	assert.Equal(t, "1 case failed", out["error"])
+	assert.Equal(t, false, out["aborted"])
+	assert.Equal(t, 1.0, out["timeouts"])
+	assert.Equal(t, 0.75, out["cost"])
+
+	scores := out["scores"].([]any)
+	require.Len(t, scores, 2)
+	accuracy := scores[0].(map[string]any)
+	assert.InDelta(t, 2.2/3, accuracy["mean"], 1e-9)
+	delete(accuracy, "mean")
+	assert.Equal(t, map[string]any{"name": "accuracy", "min": 0.2, "max": 1.0, "count": 3.0, "errors": 0.0}, accuracy)
+
+	comparison := out["comparison"].(map[string]any)
+	assert.Equal(t, "exp-0", comparison["base_experiment_id"])
+	assert.Equal(t, 2.0, comparison["improved"])
+	assert.Equal(t, 0.0, comparison["regressed"])
+
+	cases := out["cases"].([]any)
+	require.Len(t, cases, 4)
+	assert.Equal(t, map[string]any{
+		"index":     1.0,
+		"trial":     0.0,
+		"input":     "b",
+		"expected":  nil,
+		"output":    "x",
+		"scores":    map[string]any{"accuracy": 0.2, "fluency": 0.9},
+		"error":     nil,
+		"timed_out": false,
+		"cost":      0.25,
+	}, cases[1])
+	failed := cases[2].(map[string]any)
+	assert.Equal(t, true, failed["timed_out"])
+	assert.Equal(t, map[string]any{}, failed["scores"])
+	assert.Contains(t, failed["error"], "deadline")
+	assert.Equal(t, "row-d", cases[3].(map[string]any)["id"])
+}
+
+func TestResult_WriteJSON_NoComparison(t *testing.T) {
+	t.Parallel()
+
+	result := newResult(key{experimentID: "exp-1"}, nil, "", 0, nil)
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteJSON(&buf))
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Nil(t, out["error"])
+	assert.Nil(t, out["comparison"])
+	assert.Equal(t, []any{}, out["scores"])
+	assert.Equal(t, []any{}, out["cases"])
+}
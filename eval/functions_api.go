@@ -122,8 +122,13 @@ func (f *FunctionsAPI[I, R]) Scorer(ctx context.Context, opts FunctionOpts) (Sco
 			"expected": result.Expected,
 		}
 
-		// Invoke the scorer function
+		// Wait on the eval's limiter, if any, then invoke the scorer function
+		release, err := WaitLimiter(ctx)
+		if err != nil {
+			return nil, err
+		}
 		output, err := f.api.Functions().Invoke(ctx, function.ID, scorerInput)
+		release()
 		if err != nil {
 			return nil, fmt.Errorf("failed to invoke scorer: %w", err)
 		}
@@ -142,6 +147,8 @@ func (f *FunctionsAPI[I, R]) Scorer(ctx context.Context, opts FunctionOpts) (Sco
 			}
 			if scoreVal, ok := resultMap["score"].(float64); ok {
 				score.Score = scoreVal
+			} else if v, ok := resultMap["score"]; ok && v == nil {
+				score.Skipped = true
 			}
 			if metadata, ok := resultMap["metadata"].(map[string]any); ok {
 				score.Metadata = metadata
@@ -0,0 +1,144 @@
+package eval
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// limiterWaitAttr is the span attribute recording the time spent waiting on a [Limiter].
+const limiterWaitAttr = "limiter.wait_ms"
+
+// Limiter limits the rate and concurrency of task and hosted scorer calls. A single Limiter
+// can be shared by the [Opts] of several evals that run at the same time, so together they
+// stay within a provider's rate limits. It is safe for concurrent use.
+//
+// Tasks always wait on the limiter. Scorers only wait if they are hosted scorers loaded
+// with [FunctionsAPI.Scorer]; local scorers that call a provider can use [WaitLimiter].
+type Limiter struct {
+	interval time.Duration // minimum time between calls, zero for no rate limit
+	inFlight chan struct{} // nil for no concurrency limit
+
+	mu   sync.Mutex
+	next time.Time // earliest time the next call may start
+}
+
+// LimiterOpts configures a [Limiter]. Zero values mean no limit.
+type LimiterOpts struct {
+	// RequestsPerSecond is the maximum rate at which calls start.
+	RequestsPerSecond float64
+
+	// MaxInFlight is the maximum number of calls running at once.
+	MaxInFlight int
+}
+
+// NewLimiter creates a Limiter.
+func NewLimiter(opts LimiterOpts) *Limiter {
+	l := &Limiter{}
+	if opts.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / opts.RequestsPerSecond)
+	}
+	if opts.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, opts.MaxInFlight)
+	}
+	return l
+}
+
+// acquire waits until a call may start and returns a function that must be called when
+// the call is done, along with the time spent waiting.
+// A nil Limiter never waits.
+func (l *Limiter) acquire(ctx context.Context) (func(), time.Duration, error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+	start := time.Now()
+	release := func() {}
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			release = func() { <-l.inFlight }
+		case <-ctx.Done():
+			return nil, time.Since(start), context.Cause(ctx)
+		}
+	}
+
+	// the slot is only taken once it's due, so a caller that gives up waiting doesn't
+	// push back the callers after it
+	for l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		wait := l.next.Sub(now)
+		if wait <= 0 {
+			l.next = now.Add(l.interval)
+			l.mu.Unlock()
+			break
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, time.Since(start), context.Cause(ctx)
+		}
+	}
+
+	return release, time.Since(start), nil
+}
+
+// limiterKey is the context key for the limiter of the running eval.
+type limiterKey struct{}
+
+// limiterState is the limiter of the running eval and the time spent waiting on it.
+type limiterState struct {
+	limiter *Limiter
+	waited  atomic.Int64 // nanoseconds
+}
+
+// withLimiter returns a context carrying l for [WaitLimiter]. It returns ctx unchanged if l is nil.
+func withLimiter(ctx context.Context, l *Limiter) (context.Context, *limiterState) {
+	if l == nil {
+		return ctx, nil
+	}
+	state := &limiterState{limiter: l}
+	return context.WithValue(ctx, limiterKey{}, state), state
+}
+
+// record sets the time spent waiting on the limiter through this state on span.
+// It does nothing if s is nil.
+func (s *limiterState) record(span oteltrace.Span) {
+	if s != nil {
+		recordLimiterWait(span, time.Duration(s.waited.Load()))
+	}
+}
+
+// WaitLimiter waits on the [Opts.Limiter] of the eval running the scorer that ctx was passed
+// to, and returns a function that must be called when the limited call is done. The wait
+// time is recorded on the score span. It returns immediately if the eval has no limiter.
+//
+//	release, err := eval.WaitLimiter(ctx)
+//	if err != nil {
+//		return nil, err
+//	}
+//	defer release()
+func WaitLimiter(ctx context.Context) (func(), error) {
+	state, _ := ctx.Value(limiterKey{}).(*limiterState)
+	if state == nil {
+		return func() {}, nil
+	}
+	release, waited, err := state.limiter.acquire(ctx)
+	state.waited.Add(int64(waited))
+	return release, err
+}
+
+// recordLimiterWait sets the time spent waiting on the limiter as an attribute of span.
+func recordLimiterWait(span oteltrace.Span, waited time.Duration) {
+	span.SetAttributes(attribute.Int64(limiterWaitAttr, waited.Milliseconds()))
+}
@@ -0,0 +1,220 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(LimiterOpts{RequestsPerSecond: 50})
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, _, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+	// the first call starts immediately, the others 20ms apart
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(LimiterOpts{MaxInFlight: 2})
+	var tracker concurrencyTracker
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, _, err := l.acquire(context.Background())
+			assert.NoError(t, err)
+			defer release()
+			n := tracker.running.Add(1)
+			defer tracker.running.Add(-1)
+			for {
+				m := tracker.max.Load()
+				if n <= m || tracker.max.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), tracker.max.Load())
+}
+
+func TestLimiter_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(LimiterOpts{MaxInFlight: 1})
+	release, _, err := l.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, waited, err := l.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, waited, 10*time.Millisecond)
+
+	// a nil limiter never waits
+	var none *Limiter
+	release, waited, err = none.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	assert.Zero(t, waited)
+}
+
+func TestLimiter_CanceledWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(LimiterOpts{RequestsPerSecond: 10})
+	start := time.Now()
+	release, _, err := l.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+
+	// callers that give up waiting for the next slot don't take it
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, _, err := l.acquire(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}()
+	}
+	wg.Wait()
+
+	release, _, err = l.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Less(t, elapsed, 200*time.Millisecond)
+}
+
+func TestEval_LimiterSharedAcrossEvals(t *testing.T) {
+	t.Parallel()
+
+	// each eval reads its own dataset, since datasets aren't safe for concurrent use
+	cases := func() Dataset[testInput, testOutput] {
+		return NewDataset([]Case[testInput, testOutput]{
+			{Input: testInput{Value: "a"}},
+			{Input: testInput{Value: "b"}},
+			{Input: testInput{Value: "c"}},
+		})
+	}
+	var running, maxRunning atomic.Int32
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return testOutput{Result: input.Value}, nil
+	})
+
+	limiter := NewLimiter(LimiterOpts{MaxInFlight: 1})
+	first := newUnitTestEval(t, cases(), task, nil, 3)
+	second := newUnitTestEval(t, cases(), task, nil, 3)
+	first.eval.limiter = limiter
+	second.eval.limiter = limiter
+
+	var wg sync.WaitGroup
+	for _, ute := range []*unitTestEval[testInput, testOutput]{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ute.eval.run(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxRunning.Load(), "tasks of both evals should share the limit")
+
+	// every task span records its wait, and some of them must have waited
+	var maxWait int64
+	for _, ute := range []*unitTestEval[testInput, testOutput]{first, second} {
+		for _, span := range ute.exporter.Flush() {
+			if span.Name() != "task" {
+				continue
+			}
+			wait := span.Attr(limiterWaitAttr).Value.AsInt64()
+			maxWait = max(maxWait, wait)
+		}
+	}
+	assert.GreaterOrEqual(t, maxWait, int64(20))
+}
+
+func TestEval_LimiterScorers(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+	limited := func(name string) Scorer[testInput, testOutput] {
+		return NewScorer(name, func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+			release, err := WaitLimiter(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+			time.Sleep(20 * time.Millisecond)
+			return S(1), nil
+		})
+	}
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{limited("one"), limited("two")}, 1)
+	ute.eval.limiter = NewLimiter(LimiterOpts{MaxInFlight: 1})
+	ute.eval.scorerWorkers = 2
+	_, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+
+	waits := map[string]int64{}
+	for _, span := range ute.exporter.Flush() {
+		switch span.Name() {
+		case "score", "one", "two":
+			waits[span.Name()] = span.Attr(limiterWaitAttr).Value.AsInt64()
+		}
+	}
+	require.Contains(t, waits, "score")
+	// one scorer waited for the other, and the score span has the total
+	assert.GreaterOrEqual(t, max(waits["one"], waits["two"]), int64(15))
+	assert.Equal(t, waits["one"]+waits["two"], waits["score"])
+}
+
+func TestWaitLimiter(t *testing.T) {
+	t.Parallel()
+
+	release, err := WaitLimiter(context.Background())
+	require.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	ctx, _ = withLimiter(ctx, NewLimiter(LimiterOpts{MaxInFlight: 1}))
+	release, err = WaitLimiter(ctx)
+	require.NoError(t, err)
+	defer release()
+	cancel(errors.New("stopped"))
+	_, err = WaitLimiter(ctx)
+	assert.EqualError(t, err, "stopped")
+}
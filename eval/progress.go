@@ -0,0 +1,165 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress receives progress events while an eval runs. Set it with [Opts.Progress].
+//
+// Cases run concurrently when [Opts.Parallelism] is greater than 1, so implementations
+// must be safe for concurrent use.
+type Progress interface {
+	// Started is called once before any case runs. total is the number of cases that will
+	// run (including trials), or -1 if the dataset size isn't known up front. If the eval is
+	// aborted, the cases it didn't read from the dataset are never reported.
+	Started(total int)
+
+	// CaseStarted is called before a case runs. index is the position of the case in the dataset.
+	CaseStarted(index, trial int)
+
+	// Score is called for every score recorded for a case, including skipped scores.
+	Score(index, trial int, score Score)
+
+	// CaseFinished is called after a case's task and scorers have run. It is also called
+	// with a result that only has an Error when the dataset fails to return a case, or
+	// when a case isn't run because the eval was aborted.
+	CaseFinished(result CaseResult)
+
+	// Finished is called once after all cases have run.
+	Finished(result *Result)
+}
+
+// sizedDataset is implemented by datasets that know how many cases they contain.
+type sizedDataset interface {
+	Len() int
+}
+
+// datasetLen returns the number of cases in dataset, or -1 if it's unknown.
+func datasetLen[I, R any](dataset Dataset[I, R]) int {
+	if sized, ok := dataset.(sizedDataset); ok {
+		return sized.Len()
+	}
+	return -1
+}
+
+// isTerminal returns true if f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// NewProgressBar returns a [Progress] that renders a single-line progress bar to w, showing
+// completed and total cases, the number of errors and the running mean of each score.
+// It is used by default when [Opts.Quiet] is false and stderr is a terminal.
+func NewProgressBar(w io.Writer) Progress {
+	return &progressBar{
+		w:        w,
+		total:    -1,
+		interval: 100 * time.Millisecond,
+		scores:   map[string]*welford{},
+	}
+}
+
+// progressBar is the default terminal implementation of Progress.
+type progressBar struct {
+	w        io.Writer
+	interval time.Duration // minimum time between redraws
+
+	mu       sync.Mutex
+	total    int
+	finished int
+	errors   int
+	scores   map[string]*welford
+	lastDraw time.Time
+	width    int // width of the last line drawn, used to clear it
+}
+
+func (p *progressBar) Started(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+	p.draw(true)
+}
+
+func (p *progressBar) CaseStarted(index, trial int) {}
+
+func (p *progressBar) Score(index, trial int, score Score) {
+	if score.Skipped {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w, ok := p.scores[score.Name]
+	if !ok {
+		w = &welford{}
+		p.scores[score.Name] = w
+	}
+	w.add(score.Score)
+}
+
+func (p *progressBar) CaseFinished(result CaseResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished++
+	if result.Error != nil {
+		p.errors++
+	}
+	p.draw(false)
+}
+
+func (p *progressBar) Finished(result *Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if result.Aborted() {
+		// the rest of the dataset wasn't read, so end the bar at the cases that were
+		p.total = minInt(p.total, p.finished)
+	}
+	p.draw(true)
+	_, _ = fmt.Fprintln(p.w)
+}
+
+// draw redraws the progress line. Unless force is set, redraws are throttled.
+// The caller must hold p.mu.
+func (p *progressBar) draw(force bool) {
+	now := time.Now()
+	if !force && now.Sub(p.lastDraw) < p.interval {
+		return
+	}
+	p.lastDraw = now
+
+	var b strings.Builder
+	const barWidth = 20
+	if p.total > 0 {
+		filled := minInt(barWidth*p.finished/p.total, barWidth)
+		fmt.Fprintf(&b, "[%s%s] %d/%d", strings.Repeat("=", filled), strings.Repeat(" ", barWidth-filled), p.finished, p.total)
+	} else {
+		fmt.Fprintf(&b, "%d cases", p.finished)
+	}
+	fmt.Fprintf(&b, " | errors: %d", p.errors)
+
+	names := make([]string, 0, len(p.scores))
+	for name := range p.scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, " | %s: %.2f", name, p.scores[name].mean)
+	}
+
+	line := b.String()
+	pad := ""
+	if len(line) < p.width {
+		pad = strings.Repeat(" ", p.width-len(line))
+	}
+	p.width = len(line)
+	_, _ = fmt.Fprintf(p.w, "\r%s%s", line, pad)
+}
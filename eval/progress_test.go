@@ -0,0 +1,258 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingProgress is a Progress that records the events it receives.
+type recordingProgress struct {
+	mu       sync.Mutex
+	total    int
+	started  []int
+	scores   []Score
+	finished []CaseResult
+	result   *Result
+}
+
+func (p *recordingProgress) Started(total int) {
+	p.total = total
+}
+
+func (p *recordingProgress) CaseStarted(index, trial int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = append(p.started, index)
+}
+
+func (p *recordingProgress) Score(index, trial int, score Score) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.scores = append(p.scores, score)
+}
+
+func (p *recordingProgress) CaseFinished(result CaseResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished = append(p.finished, result)
+}
+
+func (p *recordingProgress) Finished(result *Result) {
+	p.result = result
+}
+
+func TestEval_Run_Progress(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "fail"}},
+		{Input: testInput{Value: "c"}},
+	})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "fail" {
+			return testOutput{}, errors.New("task failed")
+		}
+		return testOutput{Result: input.Value}, nil
+	})
+	scorers := []Scorer[testInput, testOutput]{
+		&simpleScorer{name: "accuracy", score: 1},
+	}
+
+	progress := &recordingProgress{}
+	ute := newUnitTestEval(t, cases, task, scorers, 2)
+	ute.eval.progress = progress
+	ute.eval.trials = 2
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, 6, progress.total)
+	assert.ElementsMatch(t, []int{0, 0, 1, 1, 2, 2}, progress.started)
+	assert.Len(t, progress.scores, 4)
+	assert.Len(t, progress.finished, 6)
+	assert.Same(t, result, progress.result)
+}
+
+func TestEval_Run_ProgressUnknownTotal(t *testing.T) {
+	t.Parallel()
+
+	done := false
+	dataset := &customCases[testInput, testOutput]{
+		nextFunc: func() (Case[testInput, testOutput], error) {
+			if done {
+				return Case[testInput, testOutput]{}, io.EOF
+			}
+			done = true
+			return Case[testInput, testOutput]{Input: testInput{Value: "a"}}, nil
+		},
+	}
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{}, nil
+	})
+
+	progress := &recordingProgress{}
+	ute := newUnitTestEval(t, dataset, task, nil, 1)
+	ute.eval.progress = progress
+
+	_, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, -1, progress.total)
+	assert.Len(t, progress.finished, 1)
+}
+
+func TestEval_Run_ProgressIteratorError(t *testing.T) {
+	t.Parallel()
+
+	index := 0
+	dataset := &sizedCases[testInput, testOutput]{
+		customCases: customCases[testInput, testOutput]{
+			nextFunc: func() (Case[testInput, testOutput], error) {
+				index++
+				switch index {
+				case 1, 3:
+					return Case[testInput, testOutput]{Input: testInput{Value: "a"}}, nil
+				case 2:
+					return Case[testInput, testOutput]{}, errors.New("bad row")
+				default:
+					return Case[testInput, testOutput]{}, io.EOF
+				}
+			},
+		},
+		len: 3,
+	}
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{}, nil
+	})
+
+	progress := &recordingProgress{}
+	ute := newUnitTestEval(t, dataset, task, nil, 2)
+	ute.eval.progress = progress
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 3, progress.total)
+	require.Len(t, progress.finished, 3)
+
+	var failed []CaseResult
+	for _, r := range progress.finished {
+		if r.Error != nil {
+			failed = append(failed, r)
+		}
+	}
+	require.Len(t, failed, 1)
+	assert.ErrorIs(t, failed[0].Error, errCaseIterator)
+	assert.ErrorContains(t, failed[0].Error, "bad row")
+	// the failed case isn't a result
+	assert.Len(t, result.Cases(), 2)
+}
+
+func TestEval_Run_ProgressAborted(t *testing.T) {
+	t.Parallel()
+
+	var read atomic.Int32
+	dataset := &sizedCases[testInput, testOutput]{
+		customCases: customCases[testInput, testOutput]{
+			nextFunc: func() (Case[testInput, testOutput], error) {
+				if read.Load() == 1000 {
+					return Case[testInput, testOutput]{}, io.EOF
+				}
+				read.Add(1)
+				return Case[testInput, testOutput]{Input: testInput{Value: "a"}}, nil
+			},
+		},
+		len: 1000,
+	}
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{}, errors.New("invalid API key")
+	})
+
+	progress := &recordingProgress{}
+	ute := newUnitTestEval(t, dataset, task, nil, 4)
+	ute.eval.progress = progress
+	ute.eval.errorBudget = ErrorBudget{MaxErrors: 1}
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	require.True(t, result.Aborted())
+
+	// every case read from the dataset is reported, including the ones that never ran
+	assert.Len(t, progress.finished, int(read.Load()))
+	assert.Greater(t, len(progress.finished), len(progress.started))
+	aborted := 0
+	for _, r := range progress.finished {
+		if errors.Is(r.Error, errAborted) {
+			aborted++
+		}
+	}
+	assert.GreaterOrEqual(t, aborted, len(progress.finished)-len(progress.started))
+}
+
+// sizedCases is a customCases with a known length.
+type sizedCases[I, R any] struct {
+	customCases[I, R]
+	len int
+}
+
+func (c *sizedCases[I, R]) Len() int {
+	return c.len
+}
+
+func TestProgressBar(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	bar := NewProgressBar(&buf).(*progressBar)
+	bar.interval = 0
+
+	bar.Started(4)
+	bar.CaseStarted(0, 0)
+	bar.Score(0, 0, Score{Name: "accuracy", Score: 1})
+	bar.Score(0, 0, Score{Name: "fluency", Score: 0.5})
+	bar.CaseFinished(CaseResult{})
+	bar.Score(1, 0, Score{Name: "accuracy", Score: 0})
+	bar.CaseFinished(CaseResult{Error: errors.New("failed")})
+	bar.Finished(&Result{})
+
+	out := buf.String()
+	assert.True(t, strings.HasSuffix(out, "\n"))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\r")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	assert.Equal(t, "[==========          ] 2/4 | errors: 1 | accuracy: 0.50 | fluency: 0.50", last)
+	assert.Contains(t, out, "[                    ] 0/4 | errors: 0")
+}
+
+func TestProgressBar_UnknownTotal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	bar := NewProgressBar(&buf)
+	bar.Started(-1)
+	bar.CaseFinished(CaseResult{})
+	bar.Finished(&Result{})
+
+	assert.Contains(t, buf.String(), "1 cases | errors: 0")
+}
+
+func TestProgressBar_Aborted(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	bar := NewProgressBar(&buf)
+	bar.Started(4)
+	bar.CaseFinished(CaseResult{Error: errors.New("failed")})
+	bar.Finished(&Result{aborted: errAborted})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\r")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	assert.Equal(t, "[====================] 1/1 | errors: 1", last)
+}
@@ -0,0 +1,46 @@
+package eval
+
+import "context"
+
+// loggedTrials counts the successful trials of each case already logged to an experiment,
+// so a resumed eval can skip them. It is only used by the goroutine filling the case channel.
+type loggedTrials map[string]int
+
+// loadLoggedTrials fetches the cases logged to the eval's experiment and counts the
+// successful ones by case key. Cases where any span recorded an error are run again.
+func (e *eval[I, R]) loadLoggedTrials(ctx context.Context) (loggedTrials, error) {
+	logged, err := fetchLoggedCases(ctx, e.apiClient.Experiments(), e.experimentID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(loggedTrials)
+	for _, lc := range logged {
+		if !lc.failed {
+			counts[lc.key]++
+		}
+	}
+	return counts, nil
+}
+
+// total returns the number of successful trials logged for all cases.
+func (l loggedTrials) total() int {
+	n := 0
+	for _, count := range l {
+		n += count
+	}
+	return n
+}
+
+// take returns how many of the trials of the case with the given key can be skipped
+// because they were already logged, and removes them from the counts so duplicate cases
+// are only skipped as often as they were logged. Cases without a key are never skipped.
+func (l loggedTrials) take(key string, trials int) int {
+	if key == "" {
+		return 0
+	}
+	n := minInt(l[key], trials)
+	if n > 0 {
+		l[key] -= n
+	}
+	return n
+}
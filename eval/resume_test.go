@@ -0,0 +1,121 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval_Resume(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+		{Input: testInput{Value: "b"}},
+		{Input: testInput{Value: "c"}},
+		{Input: testInput{Value: "d"}, ID: "row-d", XactID: "x1"},
+	})
+
+	var mu sync.Mutex
+	var ran []string
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		mu.Lock()
+		ran = append(ran, input.Value)
+		mu.Unlock()
+		return testOutput{Result: input.Value}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.resume = true
+	// "a" and the dataset row "d" finished, "b" failed and "c" was never logged
+	ute.eval.apiClient = newFetchServer(t, "exp-12345678", []map[string]any{
+		{"root_span_id": "r1", "input": map[string]any{"value": "a"}},
+		{"root_span_id": "r1", "span_parents": []string{"r1"}, "scores": map[string]any{"score": 1}},
+		{"root_span_id": "r2", "input": map[string]any{"value": "b"}, "error": "task failed"},
+		{"root_span_id": "r4", "input": map[string]any{"value": "changed"}, "origin": map[string]any{"id": "row-d"}},
+	})
+
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, ran)
+	assert.Equal(t, 2, result.Resumed())
+	assert.Len(t, result.Cases(), 2)
+	assert.Contains(t, result.String(), "Resumed: 2 cases already logged were skipped")
+}
+
+func TestEval_ResumeFailedCases(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}, Metadata: map[string]any{"key": "value"}},
+		{Input: testInput{Value: "b"}},
+	})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "a" {
+			return testOutput{}, errors.New("task failed")
+		}
+		return testOutput{Result: input.Value}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.caseID = func(input testInput) string { return "case-" + input.Value }
+	_, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+
+	// the failed case is still logged with its input and case ID, so it can be matched
+	found := 0
+	for _, span := range ute.exporter.Flush() {
+		if span.Name() != "eval" || span.Input().(map[string]any)["value"] != "a" {
+			continue
+		}
+		found++
+		span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{"key": "value", "case_id": "case-a"})
+		assert.False(t, span.HasAttr("braintrust.output_json"))
+	}
+	assert.Equal(t, 1, found)
+}
+
+func TestEval_ResumeTrials(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.resume = true
+	ute.eval.trials = 3
+	ute.eval.apiClient = newFetchServer(t, "exp-12345678", []map[string]any{
+		{"root_span_id": "r1", "input": map[string]any{"value": "a"}},
+		{"root_span_id": "r2", "input": map[string]any{"value": "a"}},
+	})
+
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Resumed())
+	require.Len(t, result.Cases(), 1)
+	assert.Equal(t, 2, result.Cases()[0].Trial, "only the last trial should run")
+}
+
+func TestLoggedTrials_Take(t *testing.T) {
+	t.Parallel()
+
+	keyA, err := caseKey("", "a")
+	require.NoError(t, err)
+	logged := loggedTrials{keyA: 1, "id:row": 5}
+	assert.Equal(t, 6, logged.total())
+
+	assert.Equal(t, 1, logged.take(keyA, 1))
+	assert.Equal(t, 0, logged.take(keyA, 1), "duplicate cases are only skipped as often as they were logged")
+	assert.Equal(t, 2, logged.take("id:row", 2))
+	assert.Equal(t, 0, logged.take("", 1), "cases without a key are never skipped")
+
+	var none loggedTrials
+	assert.Equal(t, 0, none.take(keyA, 1))
+	assert.Equal(t, 0, none.total())
+}
@@ -0,0 +1,103 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/https"
+)
+
+const (
+	defaultRetryBackoff    = time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how failed tasks are retried. The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a task is run, including the first attempt.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles after every attempt,
+	// up to MaxBackoff. Defaults to 1s and 30s.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Retryable reports whether a task error should be retried. If nil, every error is
+	// retried. [RetryHTTPErrors] retries rate limits and server errors from hosted functions.
+	// Timeouts and canceled contexts are never retried.
+	Retryable func(err error) bool
+}
+
+// RetryHTTPErrors reports whether err is an HTTP error from the Braintrust API
+// with a 429 (Too Many Requests) or 5xx status code.
+func RetryHTTPErrors(err error) bool {
+	var httpErr *https.HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+}
+
+// shouldRetry reports whether a task that failed with err on the given attempt (starting at 1)
+// should be run again.
+func (p RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if errors.Is(err, errTimeout) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// backoff returns the delay before the retry that follows the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+	maxDelay := p.MaxBackoff
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxBackoff
+	}
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// callWithRetry calls fn until it succeeds or the policy gives up. Every failed attempt that is
+// retried is recorded as a "retry" event on span.
+func callWithRetry[T any](ctx context.Context, p RetryPolicy, span oteltrace.Span, fn func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		val, err := callWithContext(ctx, fn)
+		if err == nil || ctx.Err() != nil || !p.shouldRetry(attempt, err) {
+			return val, err
+		}
+
+		delay := p.backoff(attempt)
+		span.AddEvent("retry", oteltrace.WithAttributes(
+			attribute.Int("retry.attempt", attempt),
+			attribute.Int64("retry.backoff_ms", delay.Milliseconds()),
+			attribute.String("exception.message", err.Error()),
+		))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, timeoutCause(ctx, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/https"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3))
+	assert.Equal(t, 800*time.Millisecond, p.backoff(4))
+	assert.Equal(t, time.Second, p.backoff(5))
+	assert.Equal(t, time.Second, p.backoff(50))
+
+	var defaults RetryPolicy
+	assert.Equal(t, time.Second, defaults.backoff(1))
+	assert.Equal(t, 30*time.Second, defaults.backoff(10))
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("boom")
+
+	var disabled RetryPolicy
+	assert.False(t, disabled.shouldRetry(1, err))
+
+	p := RetryPolicy{MaxAttempts: 3}
+	assert.True(t, p.shouldRetry(1, err))
+	assert.True(t, p.shouldRetry(2, err))
+	assert.False(t, p.shouldRetry(3, err))
+	assert.False(t, p.shouldRetry(1, fmt.Errorf("%w: slow", errTimeout)))
+	assert.False(t, p.shouldRetry(1, context.Canceled))
+
+	p.Retryable = RetryHTTPErrors
+	assert.False(t, p.shouldRetry(1, err))
+	assert.True(t, p.shouldRetry(1, fmt.Errorf("wrapped: %w", &https.HTTPError{StatusCode: 429})))
+	assert.True(t, p.shouldRetry(1, &https.HTTPError{StatusCode: 503}))
+	assert.False(t, p.shouldRetry(1, &https.HTTPError{StatusCode: 400}))
+}
+
+func TestEval_Run_RetriesTask(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "flaky"}},
+		{Input: testInput{Value: "broken"}},
+	})
+
+	attempts := map[string]int{}
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		attempts[input.Value]++
+		if input.Value == "broken" || attempts[input.Value] < 3 {
+			return testOutput{}, fmt.Errorf("attempt %d failed", attempts[input.Value])
+		}
+		return testOutput{Result: "ok"}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.retry = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attempt 3 failed")
+	assert.Equal(t, 3, attempts["flaky"])
+	assert.Equal(t, 3, attempts["broken"])
+
+	rows := result.Cases()
+	require.Len(t, rows, 2)
+	assert.NoError(t, rows[0].Error)
+	assert.Equal(t, testOutput{Result: "ok"}, rows[0].Output)
+	assert.Error(t, rows[1].Error)
+
+	spans := ute.exporter.Flush()
+	require.Len(t, spans, 5) // flaky: task, score, eval; broken: task, eval
+
+	flakyTask := spans[0]
+	flakyTask.AssertNameIs("task")
+	events := flakyTask.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "retry", events[0].Name)
+	assert.Contains(t, events[0].Attributes, attribute.Int("retry.attempt", 1))
+	assert.Contains(t, events[0].Attributes, attribute.String("exception.message", "attempt 1 failed"))
+	assert.Contains(t, events[1].Attributes, attribute.Int("retry.attempt", 2))
+
+	// two retries, then the final failure is recorded as an exception
+	brokenTask := spans[3]
+	brokenTask.AssertNameIs("task")
+	events = brokenTask.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, "retry", events[0].Name)
+	assert.Equal(t, "retry", events[1].Name)
+	assert.Equal(t, "exception", events[2].Name)
+}
+
+func TestEval_Run_RetryStopsAtCaseTimeout(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+
+	attempts := 0
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		attempts++
+		return testOutput{}, errors.New("always fails")
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.retry = RetryPolicy{MaxAttempts: 100, Backoff: time.Hour}
+	ute.eval.caseTimeout = 20 * time.Millisecond
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTimeout)
+	assert.Contains(t, err.Error(), "always fails")
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, 1, result.Timeouts())
+}
@@ -21,10 +21,22 @@ type Score struct {
 	// Score is the numeric score value.
 	Score float64
 
+	// Skipped marks the score as not applicable to the case. Skipped scores are logged
+	// as null and aren't included in summary statistics. Score is ignored if Skipped is true.
+	Skipped bool
+
 	// Metadata is optional additional metadata for this score.
 	Metadata map[string]interface{}
 }
 
+// value returns the score value to log, or nil if the score was skipped.
+func (s Score) value() *float64 {
+	if s.Skipped {
+		return nil
+	}
+	return &s.Score
+}
+
 // Scores is a collection of Score results returned by scorers.
 type Scores = []Score
 
@@ -35,6 +47,13 @@ func S(score float64) Scores {
 	return Scores{{Name: "", Score: score}}
 }
 
+// Skip is a helper function for scorers that don't apply to a case. It returns a single
+// skipped score, which defaults to the name of the scorer that creates it.
+// Skip() is equivalent to Scores{{Skipped: true}}.
+func Skip() Scores {
+	return Scores{{Name: "", Skipped: true}}
+}
+
 // ScoreFunc is a function that evaluates a task result and returns a list of Scores.
 type ScoreFunc[I, R any] func(ctx context.Context, result TaskResult[I, R]) (Scores, error)
 
@@ -0,0 +1,106 @@
+package scorers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// Weight is a component of a [Weighted] scorer.
+type Weight[I, R any] struct {
+	Scorer eval.Scorer[I, R]
+	Weight float64
+}
+
+// Weighted returns a scorer named name that runs each component scorer and records
+// their scores along with a composite score, also named name, which is the weighted
+// average of the components. A component that records several scores counts as the mean
+// of its scores, so its share of the average doesn't depend on how many it records.
+// Skipped scores are left out, and so are components whose scores are all skipped; the
+// composite is skipped if every component is. If any component fails, the scorer fails.
+//
+//	scorers.Weighted("quality",
+//		scorers.Weight[string, string]{Scorer: scorers.Levenshtein[string, string](), Weight: 1},
+//		scorers.Weight[string, string]{Scorer: judge, Weight: 3},
+//	)
+func Weighted[I, R any](name string, components ...Weight[I, R]) eval.Scorer[I, R] {
+	return eval.NewScorer(name, func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		var scores eval.Scores
+		var sum, total float64
+		weights := map[string]float64{}
+		for _, c := range components {
+			componentScores, err := c.Scorer.Run(ctx, r)
+			if err != nil {
+				return nil, fmt.Errorf("scorer %q failed: %w", c.Scorer.Name(), err)
+			}
+			var componentSum float64
+			var count int
+			for _, s := range componentScores {
+				if s.Name == "" {
+					s.Name = c.Scorer.Name()
+				}
+				scores = append(scores, s)
+				if s.Skipped {
+					continue
+				}
+				componentSum += s.Score
+				count++
+			}
+			if count == 0 {
+				continue
+			}
+			sum += c.Weight * componentSum / float64(count)
+			total += c.Weight
+			weights[c.Scorer.Name()] = c.Weight
+		}
+		if total == 0 {
+			return append(scores, eval.Score{Name: name, Skipped: true}), nil
+		}
+		return append(scores, eval.Score{
+			Name:     name,
+			Score:    sum / total,
+			Metadata: map[string]any{"weights": weights},
+		}), nil
+	})
+}
+
+// Threshold returns a scorer named name that runs scorer and converts each of its scores
+// to 1 if it is at least threshold and 0 otherwise. The original score and the threshold
+// are recorded in the metadata. Skipped scores stay skipped.
+func Threshold[I, R any](name string, scorer eval.Scorer[I, R], threshold float64) eval.Scorer[I, R] {
+	return eval.NewScorer(name, func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		scores, err := scorer.Run(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		passed := make(eval.Scores, len(scores))
+		for i, s := range scores {
+			if s.Skipped {
+				passed[i] = s
+				continue
+			}
+			pass := 0.0
+			if s.Score >= threshold {
+				pass = 1
+			}
+			passed[i] = eval.Score{
+				Name:     s.Name,
+				Score:    pass,
+				Metadata: map[string]any{"score": s.Score, "threshold": threshold},
+			}
+		}
+		return passed, nil
+	})
+}
+
+// When returns a scorer that only runs scorer for task results where cond returns true.
+// For other cases it records a skipped score, so they don't count towards the score's average.
+func When[I, R any](cond func(eval.TaskResult[I, R]) bool, scorer eval.Scorer[I, R]) eval.Scorer[I, R] {
+	return eval.NewScorer(scorer.Name(), func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		if !cond(r) {
+			return eval.Skip(), nil
+		}
+		return scorer.Run(ctx, r)
+	})
+}
@@ -0,0 +1,130 @@
+package scorers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// constScorer returns a scorer that always records the given scores.
+func constScorer(name string, scores ...float64) eval.Scorer[string, string] {
+	return eval.NewScorer(name, func(ctx context.Context, r eval.TaskResult[string, string]) (eval.Scores, error) {
+		out := make(eval.Scores, len(scores))
+		for i, s := range scores {
+			out[i] = eval.Score{Score: s}
+		}
+		return out, nil
+	})
+}
+
+func runScorer(t *testing.T, scorer eval.Scorer[string, string], r eval.TaskResult[string, string]) eval.Scores {
+	t.Helper()
+	scores, err := scorer.Run(context.Background(), r)
+	require.NoError(t, err)
+	return scores
+}
+
+func TestWeighted(t *testing.T) {
+	t.Parallel()
+
+	scorer := Weighted("quality",
+		Weight[string, string]{Scorer: constScorer("a", 1), Weight: 1},
+		Weight[string, string]{Scorer: constScorer("b", 0), Weight: 3},
+	)
+	assert.Equal(t, "quality", scorer.Name())
+
+	scores := runScorer(t, scorer, eval.TaskResult[string, string]{})
+	require.Len(t, scores, 3)
+	assert.Equal(t, eval.Score{Name: "a", Score: 1}, scores[0])
+	assert.Equal(t, eval.Score{Name: "b", Score: 0}, scores[1])
+	assert.Equal(t, "quality", scores[2].Name)
+	assert.Equal(t, 0.25, scores[2].Score)
+	assert.Equal(t, map[string]float64{"a": 1, "b": 3}, scores[2].Metadata["weights"])
+}
+
+func TestWeighted_MultiScoreComponent(t *testing.T) {
+	t.Parallel()
+
+	// the component's three scores count once, as their mean
+	multi := eval.NewScorer("multi", func(ctx context.Context, r eval.TaskResult[string, string]) (eval.Scores, error) {
+		return eval.Scores{{Name: "x", Score: 1}, {Name: "y", Score: 1}, {Name: "z", Score: 0.4}, {Name: "w", Skipped: true}}, nil
+	})
+	scorer := Weighted("quality",
+		Weight[string, string]{Scorer: multi, Weight: 1},
+		Weight[string, string]{Scorer: constScorer("b", 0), Weight: 1},
+	)
+	scores := runScorer(t, scorer, eval.TaskResult[string, string]{})
+	require.Len(t, scores, 6)
+	assert.Equal(t, "quality", scores[5].Name)
+	assert.InDelta(t, 0.4, scores[5].Score, 1e-9)
+	assert.Equal(t, map[string]float64{"multi": 1, "b": 1}, scores[5].Metadata["weights"])
+}
+
+func TestWeighted_SkippedScores(t *testing.T) {
+	t.Parallel()
+
+	never := func(eval.TaskResult[string, string]) bool { return false }
+	scorer := Weighted("quality",
+		Weight[string, string]{Scorer: constScorer("a", 0.5), Weight: 1},
+		Weight[string, string]{Scorer: When(never, constScorer("b", 0)), Weight: 3},
+	)
+	scores := runScorer(t, scorer, eval.TaskResult[string, string]{})
+	require.Len(t, scores, 3)
+	assert.Equal(t, eval.Score{Name: "b", Skipped: true}, scores[1])
+	assert.Equal(t, 0.5, scores[2].Score)
+
+	empty := Weighted[string, string]("empty", Weight[string, string]{Scorer: When(never, constScorer("b", 0)), Weight: 1})
+	scores = runScorer(t, empty, eval.TaskResult[string, string]{})
+	require.Len(t, scores, 2)
+	assert.Equal(t, eval.Score{Name: "empty", Skipped: true}, scores[1])
+}
+
+func TestWeighted_Error(t *testing.T) {
+	t.Parallel()
+
+	failing := eval.NewScorer("failing", func(ctx context.Context, r eval.TaskResult[string, string]) (eval.Scores, error) {
+		return nil, errors.New("boom")
+	})
+	scorer := Weighted("quality", Weight[string, string]{Scorer: failing, Weight: 1})
+	_, err := scorer.Run(context.Background(), eval.TaskResult[string, string]{})
+	assert.ErrorContains(t, err, `scorer "failing" failed: boom`)
+}
+
+func TestThreshold(t *testing.T) {
+	t.Parallel()
+
+	scorer := Threshold("similar_enough", Levenshtein[string, string](), 0.8)
+	assert.Equal(t, "similar_enough", scorer.Name())
+
+	scores := runScorer(t, scorer, eval.TaskResult[string, string]{Output: "colour", Expected: "color"})
+	require.Len(t, scores, 1)
+	assert.Equal(t, 1.0, scores[0].Score)
+	assert.Empty(t, scores[0].Name, "unnamed scores take the threshold scorer's name")
+	assert.Equal(t, 0.8, scores[0].Metadata["threshold"])
+	assert.InDelta(t, 5.0/6.0, scores[0].Metadata["score"], 1e-9)
+
+	scores = runScorer(t, scorer, eval.TaskResult[string, string]{Output: "cat", Expected: "color"})
+	assert.Equal(t, 0.0, scores[0].Score)
+
+	never := func(eval.TaskResult[string, string]) bool { return false }
+	skipped := Threshold("skipped", When(never, Levenshtein[string, string]()), 0.8)
+	assert.Equal(t, eval.Skip(), runScorer(t, skipped, eval.TaskResult[string, string]{}))
+}
+
+func TestWhen(t *testing.T) {
+	t.Parallel()
+
+	hasExpected := func(r eval.TaskResult[string, string]) bool { return r.Expected != "" }
+	scorer := When(hasExpected, ExactMatch[string, string]())
+	assert.Equal(t, "exact_match", scorer.Name())
+
+	assert.Equal(t, eval.Skip(), runScorer(t, scorer, eval.TaskResult[string, string]{Output: "x"}))
+	scores := runScorer(t, scorer, eval.TaskResult[string, string]{Output: "x", Expected: "x"})
+	require.Len(t, scores, 1)
+	assert.Equal(t, 1.0, scores[0].Score)
+}
@@ -0,0 +1,141 @@
+package scorers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/openai/openai-go"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// Embedder converts text to an embedding vector.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+// EmbedderFunc is a function that implements [Embedder].
+type EmbedderFunc func(ctx context.Context, text string) ([]float64, error)
+
+// Embed calls f.
+func (f EmbedderFunc) Embed(ctx context.Context, text string) ([]float64, error) {
+	return f(ctx, text)
+}
+
+// OpenAIEmbedder returns an Embedder that uses the embeddings API of client with model.
+// It works with any OpenAI-compatible embeddings endpoint set as the client's base URL.
+func OpenAIEmbedder(client *openai.Client, model string) Embedder {
+	return EmbedderFunc(func(ctx context.Context, text string) ([]float64, error) {
+		resp, err := client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+			Model: model,
+			Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String(text)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Data) == 0 {
+			return nil, fmt.Errorf("no embeddings in response")
+		}
+		return resp.Data[0].Embedding, nil
+	})
+}
+
+// ngramSize is the length of the character n-grams hashed by [NGramEmbedder].
+const ngramSize = 3
+
+// NGramEmbedder returns a deterministic Embedder that hashes the lowercased character
+// trigrams of the text into a vector with dims dimensions. It needs no network access,
+// which makes it useful for tests and offline runs, but it only captures surface
+// similarity between texts.
+func NGramEmbedder(dims int) Embedder {
+	return EmbedderFunc(func(ctx context.Context, text string) ([]float64, error) {
+		if dims <= 0 {
+			return nil, fmt.Errorf("dims must be positive")
+		}
+		vec := make([]float64, dims)
+		runes := []rune(" " + strings.ToLower(strings.Join(strings.Fields(text), " ")) + " ")
+		for i := 0; i+ngramSize <= len(runes); i++ {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(string(runes[i : i+ngramSize])))
+			vec[h.Sum32()%uint32(dims)]++
+		}
+		return vec, nil
+	})
+}
+
+// embeddingCache caches embeddings by text. It is safe for concurrent use.
+type embeddingCache struct {
+	embedder Embedder
+	mu       sync.Mutex
+	vectors  map[string][]float64
+}
+
+func (c *embeddingCache) Embed(ctx context.Context, text string) ([]float64, error) {
+	c.mu.Lock()
+	vec, ok := c.vectors[text]
+	c.mu.Unlock()
+	if ok {
+		return vec, nil
+	}
+
+	vec, err := c.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.vectors[text] = vec
+	c.mu.Unlock()
+	return vec, nil
+}
+
+// EmbeddingSimilarity scores the cosine similarity of the embeddings of the output and
+// the expected value, with negative similarities scored as 0. Embeddings of expected
+// values are cached, so they are only computed once when cases are run for several trials.
+func EmbeddingSimilarity[I any, R ~string](embedder Embedder) eval.Scorer[I, R] {
+	expectedCache := &embeddingCache{embedder: embedder, vectors: map[string][]float64{}}
+	return eval.NewScorer("embedding_similarity", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		expected, err := expectedCache.Embed(ctx, string(r.Expected))
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed expected: %w", err)
+		}
+		output, err := embedder.Embed(ctx, string(r.Output))
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed output: %w", err)
+		}
+
+		similarity, err := cosineSimilarity(output, expected)
+		if err != nil {
+			return nil, err
+		}
+		return eval.Scores{{
+			Score:    math.Max(0, similarity),
+			Metadata: map[string]any{"similarity": similarity},
+		}}, nil
+	})
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b. Two zero vectors
+// are identical and a zero vector is unrelated to any other.
+func cosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("embeddings have different dimensions: %d and %d", len(a), len(b))
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		if normA == normB {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), nil
+}
@@ -0,0 +1,113 @@
+package scorers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+func TestEmbeddingSimilarity(t *testing.T) {
+	t.Parallel()
+
+	scorer := EmbeddingSimilarity[string, string](NGramEmbedder(256))
+	assert.Equal(t, "embedding_similarity", scorer.Name())
+
+	same := score(t, scorer, "The capital of France is Paris.", "the capital of  france is paris.")
+	assert.InDelta(t, 1.0, same.Score, 1e-9)
+
+	reworded := score(t, scorer, "The capital of France is Paris.", "Paris is the capital of France.")
+	far := score(t, scorer, "The capital of France is Paris.", "Bananas are rich in potassium.")
+	assert.Greater(t, reworded.Score, far.Score)
+	assert.Less(t, reworded.Score, 1.0)
+	assert.Equal(t, reworded.Score, reworded.Metadata["similarity"])
+}
+
+func TestEmbeddingSimilarity_CachesExpected(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	embedder := EmbedderFunc(func(ctx context.Context, text string) ([]float64, error) {
+		calls = append(calls, text)
+		if text == "yes" {
+			return []float64{1, 0}, nil
+		}
+		return []float64{-1, 0}, nil
+	})
+	scorer := EmbeddingSimilarity[string, string](embedder)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1.0, score(t, scorer, "yes", "yes").Score)
+	}
+	s := score(t, scorer, "no", "yes")
+	assert.Equal(t, 0.0, s.Score, "negative similarity scores 0")
+	assert.Equal(t, -1.0, s.Metadata["similarity"])
+
+	assert.Equal(t, []string{"yes", "yes", "yes", "yes", "no"}, calls, "expected should be embedded once")
+}
+
+func TestEmbeddingSimilarity_DimensionMismatch(t *testing.T) {
+	t.Parallel()
+
+	embedder := EmbedderFunc(func(ctx context.Context, text string) ([]float64, error) {
+		return make([]float64, len(text)), nil
+	})
+	_, err := EmbeddingSimilarity[string, string](embedder).Run(context.Background(), eval.TaskResult[string, string]{Output: "a", Expected: "bb"})
+	assert.ErrorContains(t, err, "different dimensions")
+}
+
+func TestNGramEmbedder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, err := NGramEmbedder(64).Embed(ctx, "hello world")
+	require.NoError(t, err)
+	b, err := NGramEmbedder(64).Embed(ctx, "hello world")
+	require.NoError(t, err)
+	assert.Len(t, a, 64)
+	assert.Equal(t, a, b)
+
+	empty, err := NGramEmbedder(8).Embed(ctx, "")
+	require.NoError(t, err)
+	sim, err := cosineSimilarity(empty, empty)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, sim)
+
+	_, err = NGramEmbedder(0).Embed(ctx, "x")
+	assert.Error(t, err)
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/embeddings", r.URL.Path)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "text-embedding-3-small", body["model"])
+		assert.Equal(t, "hello", body["input"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object": "list", "model": "text-embedding-3-small",
+			"data": [{"object": "embedding", "index": 0, "embedding": [0.1, 0.2, 0.3]}],
+			"usage": {"prompt_tokens": 1, "total_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	client := openai.NewClient(option.WithBaseURL(server.URL), option.WithAPIKey("test-key"))
+	vec, err := OpenAIEmbedder(&client, "text-embedding-3-small").Embed(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 0.2, 0.3}, vec)
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,146 @@
+package scorers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// JSONEqual scores 1 if the output and the expected value are equal as JSON, ignoring
+// object key order and whitespace, and 0 otherwise. Strings that contain a JSON object or
+// array, such as raw model output, are parsed before comparing. Other strings are compared
+// as strings, so "123" doesn't equal 123.
+//
+// When the values differ, the paths where they differ are recorded in the metadata
+// as "diff", e.g. ["$.answer", "$.sources[1]"].
+func JSONEqual[I, R any]() eval.Scorer[I, R] {
+	return eval.NewScorer("json_equal", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		output, err := toJSONValue(r.Output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode output: %w", err)
+		}
+		expected, err := toJSONValue(r.Expected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode expected: %w", err)
+		}
+
+		diff := jsonDiff("$", output, expected, nil)
+		if len(diff) == 0 {
+			return eval.S(1), nil
+		}
+		return eval.Scores{{
+			Score:    0,
+			Metadata: map[string]any{"diff": diff},
+		}}, nil
+	})
+}
+
+// toJSONValue converts v to a generic JSON value. Numbers are kept as [json.Number]
+// so they compare exactly. A string is parsed if it looks like a JSON object or array.
+func toJSONValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := value.(string); ok && looksLikeJSONContainer(s) {
+		if parsed, err := decodeJSON([]byte(s)); err == nil {
+			return parsed, nil
+		}
+	}
+	return value, nil
+}
+
+// looksLikeJSONContainer returns true if s starts like a JSON object or array.
+func looksLikeJSONContainer(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")
+}
+
+func decodeJSON(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// jsonDiff appends the paths where a and b differ to diff.
+func jsonDiff(path string, a, b any, diff []string) []string {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return append(diff, path)
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ae, aok := av[k]
+			be, bok := bv[k]
+			if !aok || !bok {
+				// a missing key differs from an explicit null
+				diff = append(diff, path+"."+k)
+				continue
+			}
+			diff = jsonDiff(path+"."+k, ae, be, diff)
+		}
+		return diff
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			return append(diff, path)
+		}
+		for i := 0; i < max(len(av), len(bv)); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			if i >= len(av) || i >= len(bv) {
+				diff = append(diff, p)
+				continue
+			}
+			diff = jsonDiff(p, av[i], bv[i], diff)
+		}
+		return diff
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok || !numbersEqual(av, bv) {
+			return append(diff, path)
+		}
+		return diff
+	default:
+		if a != b {
+			return append(diff, path)
+		}
+		return diff
+	}
+}
+
+// numbersEqual compares JSON numbers by value, so 1 and 1.0 are equal.
+func numbersEqual(a, b json.Number) bool {
+	if strings.EqualFold(a.String(), b.String()) {
+		return true
+	}
+	af, aErr := a.Float64()
+	bf, bErr := b.Float64()
+	return aErr == nil && bErr == nil && af == bf
+}
@@ -0,0 +1,202 @@
+package scorers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// Completer sends a prompt to a model and returns the text of its response.
+//
+// Completers are called with the context of the scorer, so requests made with a client
+// traced by the traceopenai or traceanthropic middleware show up as children of the
+// score span. See [OpenAICompleter] and [AnthropicCompleter].
+type Completer interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// CompleterFunc is a function that implements [Completer].
+type CompleterFunc func(ctx context.Context, prompt string) (string, error)
+
+// Complete calls f.
+func (f CompleterFunc) Complete(ctx context.Context, prompt string) (string, error) {
+	return f(ctx, prompt)
+}
+
+// OpenAICompleter returns a Completer that sends the prompt as a user message to the
+// chat completions API of client using model.
+func OpenAICompleter(client *openai.Client, model string) Completer {
+	return CompleterFunc(func(ctx context.Context, prompt string) (string, error) {
+		resp, err := client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+			Model:    model,
+			Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage(prompt)},
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Choices) == 0 {
+			return "", fmt.Errorf("no choices in response")
+		}
+		return resp.Choices[0].Message.Content, nil
+	})
+}
+
+// anthropicMaxTokens is the max_tokens used by [AnthropicCompleter].
+const anthropicMaxTokens = 1024
+
+// AnthropicCompleter returns a Completer that sends the prompt as a user message to the
+// messages API of client using model.
+func AnthropicCompleter(client *anthropic.Client, model string) Completer {
+	return CompleterFunc(func(ctx context.Context, prompt string) (string, error) {
+		msg, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+			Model:     anthropic.Model(model),
+			MaxTokens: anthropicMaxTokens,
+			Messages:  []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock(prompt))},
+		})
+		if err != nil {
+			return "", err
+		}
+		var text strings.Builder
+		for _, block := range msg.Content {
+			if block.Type == "text" {
+				text.WriteString(block.Text)
+			}
+		}
+		return text.String(), nil
+	})
+}
+
+// JudgeOpts configures an LLM-as-judge scorer created with [LLMJudge].
+type JudgeOpts struct {
+	// Name is the name of the scorer (default: "llm_judge").
+	Name string
+
+	// Completer calls the judge model (required).
+	Completer Completer
+
+	// Prompt is a text/template for the rubric given to the judge (required). It can
+	// reference {{.Input}}, {{.Output}}, {{.Expected}} and {{.Metadata}}. Strings are
+	// inserted as is and other values as JSON.
+	Prompt string
+
+	// Choices maps each answer the judge can give to its score, e.g.
+	// {"A": 1, "B": 0.5, "C": 0} (required).
+	Choices map[string]float64
+}
+
+// choicePattern matches the line where the judge states its choice.
+var choicePattern = regexp.MustCompile(`(?im)^\W*choice\W*?:\s*(.+?)\s*$`)
+
+// LLMJudge returns a scorer that asks a model to grade the output against the expected
+// value using a rubric. The judge is asked to explain its reasoning and then pick one of
+// the choices, which is converted to a score. The choice and the judge's rationale are
+// recorded in the score metadata.
+//
+//	judge, err := scorers.LLMJudge[string, string](scorers.JudgeOpts{
+//		Completer: scorers.OpenAICompleter(&client, "gpt-4o-mini"),
+//		Prompt: `Is the answer {{.Output}} to the question {{.Input}} correct?
+//	The correct answer is {{.Expected}}.
+//	(Y) Yes
+//	(N) No`,
+//		Choices: map[string]float64{"Y": 1, "N": 0},
+//	})
+func LLMJudge[I, R any](opts JudgeOpts) (eval.Scorer[I, R], error) {
+	if opts.Completer == nil {
+		return nil, fmt.Errorf("completer is required")
+	}
+	if len(opts.Choices) == 0 {
+		return nil, fmt.Errorf("choices are required")
+	}
+	tmpl, err := template.New("judge").Option("missingkey=error").Parse(opts.Prompt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt template: %w", err)
+	}
+	name := opts.Name
+	if name == "" {
+		name = "llm_judge"
+	}
+
+	choices := make([]string, 0, len(opts.Choices))
+	for c := range opts.Choices {
+		choices = append(choices, c)
+	}
+	sort.Strings(choices)
+	instructions := fmt.Sprintf("\n\nFirst, explain your reasoning step by step. Then, on the last line, "+
+		"write \"Choice: \" followed by exactly one of %s.", strings.Join(choices, ", "))
+
+	return eval.NewScorer(name, func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		data := map[string]string{
+			"Input":    promptValue(r.Input),
+			"Output":   promptValue(r.Output),
+			"Expected": promptValue(r.Expected),
+			"Metadata": promptValue(r.Metadata),
+		}
+		var prompt strings.Builder
+		if err := tmpl.Execute(&prompt, data); err != nil {
+			return nil, fmt.Errorf("failed to render prompt: %w", err)
+		}
+		prompt.WriteString(instructions)
+
+		response, err := opts.Completer.Complete(ctx, prompt.String())
+		if err != nil {
+			return nil, fmt.Errorf("judge failed: %w", err)
+		}
+
+		choice, rationale, err := parseChoice(response, opts.Choices)
+		if err != nil {
+			return nil, err
+		}
+		return eval.Scores{{
+			Score: opts.Choices[choice],
+			Metadata: map[string]any{
+				"choice":    choice,
+				"rationale": rationale,
+			},
+		}}, nil
+	}), nil
+}
+
+// parseChoice finds the judge's choice in its response. It uses the last "Choice:" line,
+// falling back to the last non-empty line. The rest of the response is the rationale.
+func parseChoice(response string, choices map[string]float64) (string, string, error) {
+	var raw, rationale string
+	if locs := choicePattern.FindAllStringSubmatchIndex(response, -1); len(locs) > 0 {
+		loc := locs[len(locs)-1]
+		raw = response[loc[2]:loc[3]]
+		rationale = response[:loc[0]]
+	} else {
+		trimmed := strings.TrimSpace(response)
+		i := strings.LastIndex(trimmed, "\n")
+		raw, rationale = trimmed[i+1:], trimmed[:max(i, 0)]
+	}
+
+	raw = strings.Trim(raw, " \t()[]\"'*.`")
+	for c := range choices {
+		if strings.EqualFold(raw, c) {
+			return c, strings.TrimSpace(rationale), nil
+		}
+	}
+	return "", "", fmt.Errorf("judge returned an unknown choice %q", raw)
+}
+
+// promptValue formats v for a judge prompt.
+func promptValue(v any) string {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,195 @@
+package scorers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	anthropicoption "github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+	traceopenai "github.com/braintrustdata/braintrust-sdk-go/trace/contrib/openai"
+)
+
+// fakeCompleter records prompts and returns a canned response.
+type fakeCompleter struct {
+	response string
+	err      error
+	prompts  []string
+}
+
+func (f *fakeCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	return f.response, f.err
+}
+
+const testRubric = `Question: {{.Input}}
+Answer: {{.Output}}
+Expected: {{.Expected}}
+(A) correct
+(B) partially correct
+(C) wrong`
+
+var testChoices = map[string]float64{"A": 1, "B": 0.5, "C": 0}
+
+func TestLLMJudge(t *testing.T) {
+	t.Parallel()
+
+	completer := &fakeCompleter{response: "The answer names the right city.\nIt omits the country.\nChoice: B"}
+	judge, err := LLMJudge[string, string](JudgeOpts{
+		Completer: completer,
+		Prompt:    testRubric,
+		Choices:   testChoices,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "llm_judge", judge.Name())
+
+	scores, err := judge.Run(context.Background(), eval.TaskResult[string, string]{
+		Input:    "capital of France?",
+		Output:   "Paris",
+		Expected: "Paris, France",
+	})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, 0.5, scores[0].Score)
+	assert.Equal(t, "B", scores[0].Metadata["choice"])
+	assert.Equal(t, "The answer names the right city.\nIt omits the country.", scores[0].Metadata["rationale"])
+
+	require.Len(t, completer.prompts, 1)
+	assert.Contains(t, completer.prompts[0], "Question: capital of France?\nAnswer: Paris\nExpected: Paris, France\n")
+	assert.Contains(t, completer.prompts[0], `"Choice: " followed by exactly one of A, B, C.`)
+}
+
+func TestLLMJudge_StructValues(t *testing.T) {
+	t.Parallel()
+
+	type answer struct {
+		City string `json:"city"`
+	}
+	completer := &fakeCompleter{response: "A"}
+	judge, err := LLMJudge[answer, answer](JudgeOpts{
+		Name:      "correctness",
+		Completer: completer,
+		Prompt:    "{{.Output}} vs {{.Expected}} ({{.Metadata}})",
+		Choices:   testChoices,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "correctness", judge.Name())
+
+	scores, err := judge.Run(context.Background(), eval.TaskResult[answer, answer]{
+		Output:   answer{City: "Paris"},
+		Expected: answer{City: "Paris"},
+		Metadata: eval.Metadata{"difficulty": "easy"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, scores[0].Score)
+	assert.Contains(t, completer.prompts[0], `{"city":"Paris"} vs {"city":"Paris"} ({"difficulty":"easy"})`)
+}
+
+func TestParseChoice(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		response  string
+		choice    string
+		rationale string
+	}{
+		{"Choice: A", "A", ""},
+		{"reasoning\n**Choice:** (c)", "C", "reasoning"},
+		{"Choice: A\nOn reflection, no.\nchoice: B.", "B", "Choice: A\nOn reflection, no."},
+		{"looks fine\n\nA\n", "A", "looks fine"},
+	} {
+		choice, rationale, err := parseChoice(tc.response, testChoices)
+		require.NoError(t, err, tc.response)
+		assert.Equal(t, tc.choice, choice, tc.response)
+		assert.Equal(t, tc.rationale, rationale, tc.response)
+	}
+
+	_, _, err := parseChoice("I can't decide", testChoices)
+	assert.ErrorContains(t, err, "unknown choice")
+}
+
+func TestLLMJudge_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LLMJudge[string, string](JudgeOpts{Prompt: "x", Choices: testChoices})
+	assert.ErrorContains(t, err, "completer is required")
+
+	_, err = LLMJudge[string, string](JudgeOpts{Completer: &fakeCompleter{}, Prompt: "x"})
+	assert.ErrorContains(t, err, "choices are required")
+
+	_, err = LLMJudge[string, string](JudgeOpts{Completer: &fakeCompleter{}, Prompt: "{{.Output", Choices: testChoices})
+	assert.ErrorContains(t, err, "invalid prompt template")
+
+	judge, err := LLMJudge[string, string](JudgeOpts{
+		Completer: &fakeCompleter{err: errors.New("rate limited")},
+		Prompt:    testRubric,
+		Choices:   testChoices,
+	})
+	require.NoError(t, err)
+	_, err = judge.Run(context.Background(), eval.TaskResult[string, string]{})
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestOpenAICompleter_TracedAsChildSpan(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4o-mini",
+			"choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Good.\nChoice: A"}}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 3, "total_tokens": 13}}`))
+	}))
+	defer server.Close()
+
+	tp, exporter := oteltest.Setup(t)
+	client := openai.NewClient(
+		option.WithBaseURL(server.URL+"/v1/"),
+		option.WithAPIKey("test-key"),
+		option.WithMiddleware(traceopenai.NewMiddleware(traceopenai.WithTracerProvider(tp))),
+	)
+	judge, err := LLMJudge[string, string](JudgeOpts{
+		Completer: OpenAICompleter(&client, "gpt-4o-mini"),
+		Prompt:    testRubric,
+		Choices:   testChoices,
+	})
+	require.NoError(t, err)
+
+	ctx, scoreSpan := tp.Tracer(t.Name()).Start(context.Background(), "score")
+	scores, err := judge.Run(ctx, eval.TaskResult[string, string]{Input: "q", Output: "a", Expected: "a"})
+	scoreSpan.End()
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, scores[0].Score)
+
+	spans := exporter.Flush()
+	require.Len(t, spans, 2)
+	llmSpan := spans[0]
+	assert.Equal(t, scoreSpan.SpanContext().SpanID(), llmSpan.Stub.Parent.SpanID())
+}
+
+func TestAnthropicCompleter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "msg_1", "type": "message", "role": "assistant", "model": "claude-3-5-haiku-latest",
+			"content": [{"type": "text", "text": "Fine.\n"}, {"type": "text", "text": "Choice: C"}],
+			"stop_reason": "end_turn", "usage": {"input_tokens": 10, "output_tokens": 3}}`))
+	}))
+	defer server.Close()
+
+	client := anthropic.NewClient(anthropicoption.WithBaseURL(server.URL), anthropicoption.WithAPIKey("test-key"))
+	text, err := AnthropicCompleter(&client, "claude-3-5-haiku-latest").Complete(context.Background(), "grade this")
+	require.NoError(t, err)
+	assert.Equal(t, "Fine.\nChoice: C", text)
+}
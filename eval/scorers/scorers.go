@@ -0,0 +1,167 @@
+// Package scorers provides common heuristic scorers for evals.
+//
+// Each function returns an [eval.Scorer] that can be passed to [eval.Opts.Scorers]:
+//
+//	evaluator.Run(ctx, eval.Opts[string, string]{
+//		Experiment: "my-experiment",
+//		Dataset:    dataset,
+//		Task:       task,
+//		Scorers: []eval.Scorer[string, string]{
+//			scorers.ExactMatch[string, string](),
+//			scorers.Levenshtein[string, string](),
+//		},
+//	})
+//
+// Scorers compare the task's output to the case's expected value and record details
+// such as the edit distance or the differing JSON paths in the score metadata.
+package scorers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// Number is the set of numeric types supported by [NumericDiff].
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// ExactMatch scores 1 if the output is deeply equal to the expected value and 0 otherwise.
+func ExactMatch[I, R any]() eval.Scorer[I, R] {
+	return eval.NewScorer("exact_match", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		if reflect.DeepEqual(r.Output, r.Expected) {
+			return eval.S(1), nil
+		}
+		return eval.Scores{{
+			Score:    0,
+			Metadata: map[string]any{"output": r.Output, "expected": r.Expected},
+		}}, nil
+	})
+}
+
+// CaseInsensitiveMatch scores 1 if the output equals the expected value ignoring case and
+// surrounding whitespace, and 0 otherwise.
+func CaseInsensitiveMatch[I any, R ~string]() eval.Scorer[I, R] {
+	return eval.NewScorer("case_insensitive_match", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		output := strings.TrimSpace(string(r.Output))
+		expected := strings.TrimSpace(string(r.Expected))
+		if strings.EqualFold(output, expected) {
+			return eval.S(1), nil
+		}
+		return eval.Scores{{
+			Score:    0,
+			Metadata: map[string]any{"output": output, "expected": expected},
+		}}, nil
+	})
+}
+
+// Levenshtein scores the similarity of the output and the expected value as
+// 1 - distance / max(len(output), len(expected)), where distance is the Levenshtein
+// edit distance in runes. Two empty strings score 1.
+func Levenshtein[I any, R ~string]() eval.Scorer[I, R] {
+	return eval.NewScorer("levenshtein", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		output, expected := string(r.Output), string(r.Expected)
+		distance := levenshtein(output, expected)
+		maxLen := max(utf8.RuneCountInString(output), utf8.RuneCountInString(expected))
+
+		score := 1.0
+		if maxLen > 0 {
+			score = 1 - float64(distance)/float64(maxLen)
+		}
+		return eval.Scores{{
+			Score:    score,
+			Metadata: map[string]any{"distance": distance},
+		}}, nil
+	})
+}
+
+// levenshtein returns the edit distance between a and b in runes.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
+
+// NumericDiff scores how close the output is to the expected value as
+// 1 - |output - expected| / max(|output|, |expected|), clamped to [0, 1].
+// Equal values, including two zeros, score 1.
+func NumericDiff[I any, R Number]() eval.Scorer[I, R] {
+	return eval.NewScorer("numeric_diff", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		output, expected := float64(r.Output), float64(r.Expected)
+		diff := math.Abs(output - expected)
+
+		score := 1.0
+		if diff > 0 {
+			score = math.Max(0, 1-diff/math.Max(math.Abs(output), math.Abs(expected)))
+		}
+		return eval.Scores{{
+			Score:    score,
+			Metadata: map[string]any{"diff": diff},
+		}}, nil
+	})
+}
+
+// Regex scores 1 if the output matches pattern and 0 otherwise. The expected value is
+// not used. It returns an error if pattern can't be compiled.
+func Regex[I any, R ~string](pattern string) (eval.Scorer[I, R], error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return eval.NewScorer("regex", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		meta := map[string]any{"pattern": pattern}
+		output := string(r.Output)
+		loc := re.FindStringIndex(output)
+		if loc == nil {
+			return eval.Scores{{Score: 0, Metadata: meta}}, nil
+		}
+		meta["match"] = output[loc[0]:loc[1]]
+		return eval.Scores{{Score: 1, Metadata: meta}}, nil
+	}), nil
+}
+
+// ContainsAll scores the fraction of substrings that appear in the output. An empty list
+// of substrings scores 1. The substrings that weren't found are recorded in the metadata.
+func ContainsAll[I any, R ~string](substrings ...string) eval.Scorer[I, R] {
+	return eval.NewScorer("contains_all", func(ctx context.Context, r eval.TaskResult[I, R]) (eval.Scores, error) {
+		if len(substrings) == 0 {
+			return eval.S(1), nil
+		}
+
+		missing := []string{}
+		for _, s := range substrings {
+			if !strings.Contains(string(r.Output), s) {
+				missing = append(missing, s)
+			}
+		}
+		found := len(substrings) - len(missing)
+		return eval.Scores{{
+			Score:    float64(found) / float64(len(substrings)),
+			Metadata: map[string]any{"missing": missing},
+		}}, nil
+	})
+}
@@ -0,0 +1,141 @@
+package scorers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/eval"
+)
+
+// score runs scorer on output and expected and returns its single score.
+func score[I, R any](t *testing.T, scorer eval.Scorer[I, R], output, expected R) eval.Score {
+	t.Helper()
+	scores, err := scorer.Run(context.Background(), eval.TaskResult[I, R]{Output: output, Expected: expected})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	return scores[0]
+}
+
+func TestExactMatch(t *testing.T) {
+	t.Parallel()
+
+	type answer struct {
+		Text    string
+		Sources []string
+	}
+	scorer := ExactMatch[string, answer]()
+	assert.Equal(t, "exact_match", scorer.Name())
+
+	a := answer{Text: "Paris", Sources: []string{"wiki"}}
+	assert.Equal(t, 1.0, score(t, scorer, a, answer{Text: "Paris", Sources: []string{"wiki"}}).Score)
+
+	s := score(t, scorer, a, answer{Text: "Paris"})
+	assert.Equal(t, 0.0, s.Score)
+	assert.Equal(t, answer{Text: "Paris"}, s.Metadata["expected"])
+}
+
+func TestCaseInsensitiveMatch(t *testing.T) {
+	t.Parallel()
+
+	scorer := CaseInsensitiveMatch[string, string]()
+	assert.Equal(t, 1.0, score(t, scorer, " PARIS\n", "paris").Score)
+	assert.Equal(t, 0.0, score(t, scorer, "London", "Paris").Score)
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	scorer := Levenshtein[string, string]()
+
+	s := score(t, scorer, "kitten", "sitting")
+	assert.InDelta(t, 1-3.0/7.0, s.Score, 1e-9)
+	assert.Equal(t, 3, s.Metadata["distance"])
+
+	assert.Equal(t, 1.0, score(t, scorer, "", "").Score)
+	assert.Equal(t, 0.0, score(t, scorer, "abc", "").Score)
+	assert.Equal(t, 1, score(t, scorer, "café", "cafe").Metadata["distance"], "distance is counted in runes")
+}
+
+func TestNumericDiff(t *testing.T) {
+	t.Parallel()
+
+	scorer := NumericDiff[string, float64]()
+	assert.Equal(t, 1.0, score(t, scorer, 0, 0).Score)
+	assert.Equal(t, 1.0, score(t, scorer, 4.2, 4.2).Score)
+
+	s := score(t, scorer, 90, 100)
+	assert.InDelta(t, 0.9, s.Score, 1e-9)
+	assert.Equal(t, 10.0, s.Metadata["diff"])
+
+	assert.Equal(t, 0.0, score(t, scorer, -5, 5).Score)
+	assert.InDelta(t, 0.5, score(t, NumericDiff[string, int](), 2, 4).Score, 1e-9)
+}
+
+func TestJSONEqual(t *testing.T) {
+	t.Parallel()
+
+	scorer := JSONEqual[string, any]()
+	assert.Equal(t, 1.0, score[string, any](t, scorer, map[string]any{"a": 1, "b": []any{1.0, "x"}}, `{"b": [1, "x"], "a": 1.0}`).Score)
+
+	s := score[string, any](t, scorer,
+		map[string]any{"a": 1, "b": []any{1, 2}, "c": "same"},
+		map[string]any{"a": 2, "b": []any{1}, "c": "same", "d": true},
+	)
+	assert.Equal(t, 0.0, s.Score)
+	assert.Equal(t, []string{"$.a", "$.b[1]", "$.d"}, s.Metadata["diff"])
+
+	s = score[string, any](t, scorer, map[string]any{"x": nil}, map[string]any{})
+	assert.Equal(t, 0.0, s.Score)
+	assert.Equal(t, []string{"$.x"}, s.Metadata["diff"])
+	assert.Equal(t, 0.0, score[string, any](t, scorer, map[string]any{}, `{"x": null}`).Score)
+	assert.Equal(t, 1.0, score[string, any](t, scorer, map[string]any{"x": nil}, `{"x": null}`).Score)
+
+	// only strings that look like objects or arrays are parsed
+	assert.Equal(t, 0.0, score[string, any](t, scorer, "123", 123).Score)
+	assert.Equal(t, 0.0, score[string, any](t, scorer, "true", true).Score)
+	assert.Equal(t, 0.0, score[string, any](t, scorer, "null", nil).Score)
+	assert.Equal(t, 1.0, score[string, any](t, scorer, " [1, 2]", []any{1, 2}).Score)
+
+	type result struct {
+		Answer string `json:"answer"`
+	}
+	structScorer := JSONEqual[string, result]()
+	assert.Equal(t, 1.0, score(t, structScorer, result{Answer: "4"}, result{Answer: "4"}).Score)
+
+	stringScorer := JSONEqual[string, string]()
+	assert.Equal(t, 1.0, score(t, stringScorer, `{"x": [1, 2]}`, "{\n  \"x\": [1, 2]\n}").Score)
+	assert.Equal(t, 0.0, score(t, stringScorer, "not json", "also not json").Score)
+	assert.Equal(t, 1.0, score(t, stringScorer, "plain", "plain").Score)
+}
+
+func TestRegex(t *testing.T) {
+	t.Parallel()
+
+	scorer, err := Regex[string, string](`\d{3}-\d{4}`)
+	require.NoError(t, err)
+
+	s := score(t, scorer, "call 555-1234 now", "")
+	assert.Equal(t, 1.0, s.Score)
+	assert.Equal(t, "555-1234", s.Metadata["match"])
+
+	s = score(t, scorer, "no number", "")
+	assert.Equal(t, 0.0, s.Score)
+	assert.Equal(t, `\d{3}-\d{4}`, s.Metadata["pattern"])
+
+	_, err = Regex[string, string](`(`)
+	assert.Error(t, err)
+}
+
+func TestContainsAll(t *testing.T) {
+	t.Parallel()
+
+	scorer := ContainsAll[string, string]("Paris", "France", "Europe")
+	s := score(t, scorer, "Paris is the capital of France", "")
+	assert.InDelta(t, 2.0/3.0, s.Score, 1e-9)
+	assert.Equal(t, []string{"Europe"}, s.Metadata["missing"])
+
+	assert.Equal(t, 1.0, score(t, ContainsAll[string, string](), "anything", "").Score)
+}
@@ -0,0 +1,122 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// concurrencyTracker records the maximum number of scorers running at once.
+type concurrencyTracker struct {
+	running atomic.Int32
+	max     atomic.Int32
+}
+
+func (c *concurrencyTracker) scorer(name string, score float64, err error) Scorer[testInput, testOutput] {
+	return NewScorer(name, func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+		n := c.running.Add(1)
+		defer c.running.Add(-1)
+		for {
+			m := c.max.Load()
+			if n <= m || c.max.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+		return S(score), nil
+	})
+}
+
+func TestEval_ScorerParallelism(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	var tracker concurrencyTracker
+	scorers := []Scorer[testInput, testOutput]{
+		tracker.scorer("one", 1, nil),
+		tracker.scorer("two", 0.5, nil),
+		tracker.scorer("three", 0, errors.New("judge unavailable")),
+		tracker.scorer("four", 0.25, nil),
+	}
+
+	ute := newUnitTestEval(t, cases, task, scorers, 1)
+	ute.eval.scorerWorkers = 2
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errScorer)
+	assert.Equal(t, int32(2), tracker.max.Load(), "scorers should run two at a time")
+	assert.Equal(t, map[string]float64{"one": 1, "two": 0.5, "four": 0.25}, result.Cases()[0].Scores)
+
+	spans := ute.exporter.Flush()
+	byName := map[string]int{}
+	for i, span := range spans {
+		byName[span.Name()] = i
+	}
+	require.Contains(t, byName, "score")
+	scoreSpan := spans[byName["score"]]
+
+	// the score span keeps the aggregate format
+	scoreSpan.AssertJSONAttrEquals("braintrust.scores", map[string]any{"one": 1.0, "two": 0.5, "four": 0.25})
+	scoreSpan.AssertJSONAttrEquals("braintrust.output", map[string]any{
+		"one":  map[string]any{"score": 1.0},
+		"two":  map[string]any{"score": 0.5},
+		"four": map[string]any{"score": 0.25},
+	})
+	assert.Equal(t, codes.Error, scoreSpan.Status().Code)
+
+	// each scorer has its own child span
+	for _, name := range []string{"one", "two", "three", "four"} {
+		require.Contains(t, byName, name)
+		span := spans[byName[name]]
+		assert.Equal(t, scoreSpan.Stub.SpanContext.SpanID(), span.Stub.Parent.SpanID(), name)
+		span.AssertJSONAttrEquals("braintrust.span_attributes", map[string]any{"type": "score", "name": name})
+		assert.GreaterOrEqual(t, span.Stub.EndTime.Sub(span.Stub.StartTime), 20*time.Millisecond)
+	}
+	failed := spans[byName["three"]]
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Status().Description, "judge unavailable")
+	assert.Equal(t, codes.Unset, spans[byName["one"]].Status().Code)
+}
+
+func TestEval_ScorersRunInOrderByDefault(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{{Input: testInput{Value: "a"}}})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	var mu sync.Mutex
+	var order []string
+	scorer := func(name string) Scorer[testInput, testOutput] {
+		return NewScorer(name, func(ctx context.Context, result TaskResult[testInput, testOutput]) (Scores, error) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return S(1), nil
+		})
+	}
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{scorer("a"), scorer("b"), scorer("c")}, 1)
+	_, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+
+	// no child spans: task, score and eval
+	assert.Len(t, ute.exporter.Flush(), 3)
+}
@@ -0,0 +1,237 @@
+package eval
+
+import (
+	"sort"
+	"sync"
+)
+
+// ScoreSummary contains aggregate statistics for a single score across all cases in an eval.
+type ScoreSummary struct {
+	// Name is the name of the score.
+	Name string
+
+	// Mean, Min and Max are computed over all recorded values of the score.
+	// They are zero if Count is zero.
+	Mean float64
+	Min  float64
+	Max  float64
+
+	// Count is the number of cases that recorded a value for this score.
+	Count int
+
+	// Errors is the number of cases that are missing this score because the task or the
+	// scorer that produces it returned an error. A scorer's scores are known from the cases
+	// where it succeeded; a scorer that never succeeded is assumed to produce a score named
+	// after itself.
+	Errors int
+}
+
+// CaseResult contains the outcome of running a single case in an eval.
+type CaseResult struct {
+	Input    any
+	Expected any
+	Output   any // Zero value if the task failed
+	Tags     []string
+	Metadata Metadata
+
+	// ID is the dataset record ID if the case came from a dataset.
+	ID string
+
+	// Scores maps score names to their values for this case. Skipped scores are not included.
+	Scores map[string]float64
+
+	// Error is the task or scorer error for this case, if any.
+	Error error
+
+	// TimedOut is true if the task or a scorer exceeded its timeout.
+	TimedOut bool
+
+	// Trial is the zero-based trial index when each case is run more than once.
+	Trial int
+
+	// Cost is the estimated cost in US dollars of the LLM calls made by the task and scorers,
+	// as recorded by the traced clients of the contrib packages. It is zero if the models
+	// have no price in the trace/pricing package.
+	Cost float64
+
+	index         int                 // position of the case in the dataset
+	scorerScores  map[string][]string // names of the scores produced by each scorer that succeeded
+	failedScorers []string            // names of the scorers that returned an error
+	unscored      []string            // names of the scorers that didn't run because the task failed
+	key           string              // matches the case across experiments, see matchKey
+}
+
+// TrialSummary summarizes all trials of a single case when each case is run more than once.
+type TrialSummary struct {
+	Input    any
+	Expected any
+	ID       string // Dataset record ID if the case came from a dataset
+
+	Trials int // Number of trials run
+	Errors int // Number of trials that returned an error
+
+	// Scores maps score names to statistics over the trials that recorded the score.
+	Scores map[string]ScoreStats
+}
+
+// ScoreStats contains the mean and variance of a score over the trials of a case.
+type ScoreStats struct {
+	Mean     float64
+	Variance float64 // Sample variance, zero if Count is less than 2
+	Count    int
+}
+
+// summarizeTrials groups cases by their position in the dataset and computes score statistics
+// over the trials of each. cases must be in dataset order.
+func summarizeTrials(cases []CaseResult) []TrialSummary {
+	var summaries []TrialSummary
+	var moments []map[string]*welford
+
+	for i, c := range cases {
+		if i == 0 || c.index != cases[i-1].index {
+			summaries = append(summaries, TrialSummary{
+				Input:    c.Input,
+				Expected: c.Expected,
+				ID:       c.ID,
+				Scores:   map[string]ScoreStats{},
+			})
+			moments = append(moments, map[string]*welford{})
+		}
+		s := &summaries[len(summaries)-1]
+		m := moments[len(moments)-1]
+
+		s.Trials++
+		if c.Error != nil {
+			s.Errors++
+		}
+		for name, val := range c.Scores {
+			if m[name] == nil {
+				m[name] = &welford{}
+			}
+			m[name].add(val)
+		}
+	}
+
+	for i, m := range moments {
+		for name, w := range m {
+			summaries[i].Scores[name] = ScoreStats{Mean: w.mean, Variance: w.variance(), Count: w.count}
+		}
+	}
+	return summaries
+}
+
+// welford computes a running mean and variance with Welford's algorithm, which avoids
+// accumulating large sums.
+type welford struct {
+	count int
+	mean  float64
+	m2    float64
+}
+
+func (w *welford) add(val float64) {
+	w.count++
+	delta := val - w.mean
+	w.mean += delta / float64(w.count)
+	w.m2 += delta * (val - w.mean)
+}
+
+func (w *welford) variance() float64 {
+	if w.count < 2 {
+		return 0
+	}
+	return w.m2 / float64(w.count-1)
+}
+
+// summarizeScores computes a ScoreSummary for every score name seen in cases, and every
+// score a failed scorer is expected to produce. The summaries are sorted by name.
+func summarizeScores(cases []CaseResult) []ScoreSummary {
+	// the names of the scores each scorer produced in any case
+	produced := make(map[string]map[string]bool)
+	for _, c := range cases {
+		for scorer, names := range c.scorerScores {
+			if produced[scorer] == nil {
+				produced[scorer] = make(map[string]bool, len(names))
+			}
+			for _, name := range names {
+				produced[scorer][name] = true
+			}
+		}
+	}
+
+	byName := make(map[string]*ScoreSummary)
+	get := func(name string) *ScoreSummary {
+		s, ok := byName[name]
+		if !ok {
+			s = &ScoreSummary{Name: name}
+			byName[name] = s
+		}
+		return s
+	}
+
+	for _, c := range cases {
+		for name, val := range c.Scores {
+			s := get(name)
+			if s.Count == 0 || val < s.Min {
+				s.Min = val
+			}
+			if s.Count == 0 || val > s.Max {
+				s.Max = val
+			}
+			// running mean avoids accumulating a large sum
+			s.Count++
+			s.Mean += (val - s.Mean) / float64(s.Count)
+		}
+		// count each missing score once, even if several scorers produce it
+		missing := make(map[string]bool)
+		addMissing := func(scorers []string) {
+			for _, scorer := range scorers {
+				if len(produced[scorer]) == 0 {
+					missing[scorer] = true
+				}
+				for name := range produced[scorer] {
+					missing[name] = true
+				}
+			}
+		}
+		addMissing(c.failedScorers)
+		addMissing(c.unscored)
+		for name := range missing {
+			get(name).Errors++
+		}
+	}
+
+	summaries := make([]ScoreSummary, 0, len(byName))
+	for _, s := range byName {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
+
+// lockedCaseResults is a thread-safe list of case results.
+type lockedCaseResults struct {
+	mu      sync.Mutex
+	results []CaseResult
+}
+
+func (l *lockedCaseResults) append(r CaseResult) {
+	l.mu.Lock()
+	l.results = append(l.results, r)
+	l.mu.Unlock()
+}
+
+// get returns the results in dataset order, with the trials of each case in order.
+func (l *lockedCaseResults) get() []CaseResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sort.SliceStable(l.results, func(i, j int) bool {
+		a, b := l.results[i], l.results[j]
+		if a.index != b.index {
+			return a.index < b.index
+		}
+		return a.Trial < b.Trial
+	})
+	return l.results
+}
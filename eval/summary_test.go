@@ -0,0 +1,224 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeScores(t *testing.T) {
+	t.Parallel()
+
+	produced := map[string][]string{"accuracy": {"accuracy"}, "fluency": {"fluency"}}
+	cases := []CaseResult{
+		{Scores: map[string]float64{"accuracy": 1.0, "fluency": 0.5}, scorerScores: produced},
+		{Scores: map[string]float64{"accuracy": 0.0}, scorerScores: map[string][]string{"accuracy": {"accuracy"}}, failedScorers: []string{"fluency"}},
+		{Scores: map[string]float64{"accuracy": 0.5, "fluency": 0.7}, scorerScores: produced},
+		{Error: errors.New("task failed"), unscored: []string{"accuracy", "fluency"}},
+	}
+
+	summaries := summarizeScores(cases)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "accuracy", summaries[0].Name)
+	assert.InDelta(t, 0.5, summaries[0].Mean, 1e-9)
+	assert.Equal(t, 0.0, summaries[0].Min)
+	assert.Equal(t, 1.0, summaries[0].Max)
+	assert.Equal(t, 3, summaries[0].Count)
+	assert.Equal(t, 1, summaries[0].Errors)
+
+	assert.Equal(t, "fluency", summaries[1].Name)
+	assert.InDelta(t, 0.6, summaries[1].Mean, 1e-9)
+	assert.Equal(t, 0.5, summaries[1].Min)
+	assert.Equal(t, 0.7, summaries[1].Max)
+	assert.Equal(t, 2, summaries[1].Count)
+	assert.Equal(t, 2, summaries[1].Errors)
+}
+
+func TestSummarizeScores_ErrorsByScoreName(t *testing.T) {
+	t.Parallel()
+
+	// the "quality" scorer produces "relevance" and "tone"
+	cases := []CaseResult{
+		{Scores: map[string]float64{"relevance": 1, "tone": 0.5}, scorerScores: map[string][]string{"quality": {"relevance", "tone"}}},
+		{failedScorers: []string{"quality"}},
+		{unscored: []string{"quality", "never_ran"}},
+	}
+
+	summaries := summarizeScores(cases)
+	require.Len(t, summaries, 3)
+	assert.Equal(t, ScoreSummary{Name: "never_ran", Errors: 1}, summaries[0])
+	assert.Equal(t, ScoreSummary{Name: "relevance", Mean: 1, Min: 1, Max: 1, Count: 1, Errors: 2}, summaries[1])
+	assert.Equal(t, ScoreSummary{Name: "tone", Mean: 0.5, Min: 0.5, Max: 0.5, Count: 1, Errors: 2}, summaries[2])
+}
+
+func TestSummarizeScores_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, summarizeScores(nil))
+}
+
+func TestEval_Run_ResultScores(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}, Expected: testOutput{Result: "a"}},
+		{Input: testInput{Value: "b"}, Expected: testOutput{Result: "x"}},
+		{Input: testInput{Value: "fail"}},
+		{Input: testInput{Value: "c"}, Expected: testOutput{Result: "c"}, ID: "row-c"},
+	})
+
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "fail" {
+			return testOutput{}, errors.New("task failed")
+		}
+		return testOutput{Result: input.Value}, nil
+	})
+
+	scorers := []Scorer[testInput, testOutput]{
+		NewScorer("exact", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+			if r.Output == r.Expected {
+				return S(1), nil
+			}
+			return S(0), nil
+		}),
+		NewScorer("flaky", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+			if r.Input.Value == "b" {
+				return nil, errors.New("flaky scorer")
+			}
+			return S(0.5), nil
+		}),
+	}
+
+	ute := newUnitTestEval(t, cases, task, scorers, 3)
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+
+	exact, ok := result.Score("exact")
+	require.True(t, ok)
+	assert.InDelta(t, 2.0/3.0, exact.Mean, 1e-9)
+	assert.Equal(t, 0.0, exact.Min)
+	assert.Equal(t, 1.0, exact.Max)
+	assert.Equal(t, 3, exact.Count)
+	assert.Equal(t, 1, exact.Errors) // the failed task
+
+	flaky, ok := result.Score("flaky")
+	require.True(t, ok)
+	assert.Equal(t, 0.5, flaky.Mean)
+	assert.Equal(t, 2, flaky.Count)
+	assert.Equal(t, 2, flaky.Errors) // the failed scorer and the failed task
+
+	_, ok = result.Score("missing")
+	assert.False(t, ok)
+	assert.Len(t, result.Scores(), 2)
+
+	// Cases are returned in dataset order regardless of parallelism
+	rows := result.Cases()
+	require.Len(t, rows, 4)
+	assert.Equal(t, testInput{Value: "a"}, rows[0].Input)
+	assert.Equal(t, testOutput{Result: "a"}, rows[0].Output)
+	assert.Equal(t, map[string]float64{"exact": 1, "flaky": 0.5}, rows[0].Scores)
+	assert.NoError(t, rows[0].Error)
+
+	assert.Equal(t, map[string]float64{"exact": 0}, rows[1].Scores)
+	assert.ErrorIs(t, rows[1].Error, errScorer)
+
+	assert.Nil(t, rows[2].Scores)
+	assert.ErrorIs(t, rows[2].Error, errTaskRun)
+
+	assert.Equal(t, "row-c", rows[3].ID)
+	assert.Contains(t, result.String(), "exact: 66.67% (n=3, errors=1)")
+}
+
+func TestSummarizeTrials(t *testing.T) {
+	t.Parallel()
+
+	cases := []CaseResult{
+		{Input: "a", index: 0, Trial: 0, Scores: map[string]float64{"accuracy": 1}},
+		{Input: "a", index: 0, Trial: 1, Scores: map[string]float64{"accuracy": 0}},
+		{Input: "a", index: 0, Trial: 2, Scores: map[string]float64{"accuracy": 1}},
+		{Input: "b", index: 1, Trial: 0, Scores: map[string]float64{"accuracy": 0.5}},
+		{Input: "b", index: 1, Trial: 1, Error: errors.New("task failed")},
+	}
+
+	summaries := summarizeTrials(cases)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "a", summaries[0].Input)
+	assert.Equal(t, 3, summaries[0].Trials)
+	assert.Equal(t, 0, summaries[0].Errors)
+	accuracy := summaries[0].Scores["accuracy"]
+	assert.Equal(t, 3, accuracy.Count)
+	assert.InDelta(t, 2.0/3.0, accuracy.Mean, 1e-9)
+	assert.InDelta(t, 1.0/3.0, accuracy.Variance, 1e-9)
+
+	assert.Equal(t, "b", summaries[1].Input)
+	assert.Equal(t, 2, summaries[1].Trials)
+	assert.Equal(t, 1, summaries[1].Errors)
+	assert.Equal(t, ScoreStats{Mean: 0.5, Variance: 0, Count: 1}, summaries[1].Scores["accuracy"])
+}
+
+func TestEval_Run_Trials(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}, Metadata: map[string]interface{}{"key": "value"}},
+		{Input: testInput{Value: "b"}},
+	})
+
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	task := func(ctx context.Context, input testInput, hooks *TaskHooks) (TaskOutput[testOutput], error) {
+		mu.Lock()
+		seen[input.Value] = append(seen[input.Value], hooks.TrialIndex)
+		mu.Unlock()
+		return TaskOutput[testOutput]{Value: testOutput{Result: fmt.Sprint(hooks.TrialIndex)}}, nil
+	}
+	// alternate between 0 and 1 across trials
+	scorer := NewScorer("parity", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+		if r.Output.Result == "1" {
+			return S(1), nil
+		}
+		return S(0), nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, []Scorer[testInput, testOutput]{scorer}, 3)
+	ute.eval.trials = 2
+
+	result, err := ute.eval.run(context.Background())
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []int{0, 1}, seen["a"])
+	assert.ElementsMatch(t, []int{0, 1}, seen["b"])
+
+	rows := result.Cases()
+	require.Len(t, rows, 4)
+	for i, row := range rows {
+		assert.Equal(t, i%2, row.Trial)
+	}
+
+	trials := result.Trials()
+	require.Len(t, trials, 2)
+	assert.Equal(t, testInput{Value: "a"}, trials[0].Input)
+	assert.Equal(t, 2, trials[0].Trials)
+	assert.Equal(t, ScoreStats{Mean: 0.5, Variance: 0.5, Count: 2}, trials[0].Scores["parity"])
+
+	// each trial has its own eval span tagged with the trial index
+	var trialIndexes []any
+	for _, span := range ute.exporter.Flush() {
+		if span.Name() != "eval" {
+			continue
+		}
+		meta := span.Metadata()
+		trialIndexes = append(trialIndexes, meta["trial_index"])
+		if span.Input().(map[string]any)["value"] == "a" {
+			assert.Equal(t, "value", meta["key"])
+		}
+	}
+	assert.ElementsMatch(t, []any{0.0, 1.0, 0.0, 1.0}, trialIndexes)
+}
@@ -22,6 +22,9 @@ type TaskHooks struct {
 	Expected any      // Not usually used in tasks, so this is untyped
 	Metadata Metadata // Case metadata
 	Tags     []string // Case tags
+
+	// TrialIndex is the zero-based index of the trial when each case is run more than once.
+	TrialIndex int
 }
 
 // TaskOutput wraps the output value from a task.
@@ -0,0 +1,56 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// withTimeout returns a context that is canceled after d with a cause wrapping errTimeout.
+// If d is not positive, ctx is returned unchanged.
+func withTimeout(ctx context.Context, d time.Duration, what string) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, d, fmt.Errorf("%w: %s exceeded %s", errTimeout, what, d))
+}
+
+// callWithContext calls fn and waits until it returns or ctx is done, whichever comes first.
+// If ctx is done first, fn is left running in the background and its result is discarded,
+// so a task that ignores its context can't stall an eval worker.
+func callWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	if ctx.Done() == nil {
+		return fn()
+	}
+
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, err := fn()
+		done <- result{val: val, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.val, timeoutCause(ctx, r.err)
+	case <-ctx.Done():
+		var zero T
+		return zero, context.Cause(ctx)
+	}
+}
+
+// timeoutCause attaches the timeout cause of ctx to err, so errors returned by functions
+// that honored the deadline (e.g. context.DeadlineExceeded) are recognized as timeouts.
+func timeoutCause(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, errTimeout) {
+		return err
+	}
+	if cause := context.Cause(ctx); errors.Is(cause, errTimeout) {
+		return fmt.Errorf("%w: %w", cause, err)
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestCallWithContext_ReturnsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := withTimeout(context.Background(), 10*time.Millisecond, "test")
+	defer cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	// fn ignores its context, but callWithContext must still return
+	_, err := callWithContext(ctx, func() (int, error) {
+		<-block
+		return 1, nil
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTimeout)
+	assert.Contains(t, err.Error(), "test exceeded 10ms")
+}
+
+func TestCallWithContext_AnnotatesDeadlineErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := withTimeout(context.Background(), 10*time.Millisecond, "test")
+	defer cancel()
+
+	// fn honors its context and returns the context error
+	_, err := callWithContext(ctx, func() (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTimeout)
+}
+
+func TestCallWithContext_NoTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := withTimeout(context.Background(), 0, "test")
+	defer cancel()
+
+	val, err := callWithContext(ctx, func() (int, error) {
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+
+	fnErr := errors.New("fn failed")
+	_, err = callWithContext(ctx, func() (int, error) {
+		return 0, fnErr
+	})
+	assert.Equal(t, fnErr, err)
+}
+
+func TestEval_Run_CaseTimeout(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "fast"}},
+		{Input: testInput{Value: "hang"}},
+	})
+
+	block := make(chan struct{})
+	defer close(block)
+
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		if input.Value == "hang" {
+			<-block // ignores ctx entirely
+		}
+		return testOutput{Result: input.Value}, nil
+	})
+
+	ute := newUnitTestEval(t, cases, task, nil, 1)
+	ute.eval.caseTimeout = 20 * time.Millisecond
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTimeout)
+	assert.ErrorIs(t, err, errTaskRun)
+	assert.Equal(t, 1, result.Timeouts())
+
+	rows := result.Cases()
+	require.Len(t, rows, 2)
+	assert.False(t, rows[0].TimedOut)
+	assert.True(t, rows[1].TimedOut)
+	assert.Contains(t, result.String(), "Timeouts: 1")
+
+	spans := ute.exporter.Flush()
+	require.Len(t, spans, 5) // fast: task, score, eval; hang: task, eval
+	taskSpan := spans[3]
+	taskSpan.AssertNameIs("task")
+	assert.Equal(t, codes.Error, taskSpan.Status().Code)
+	events := taskSpan.Events()
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].Attributes, attribute.String("exception.type", "ErrTimeout"))
+}
+
+func TestEval_Run_ScorerTimeout(t *testing.T) {
+	t.Parallel()
+
+	cases := NewDataset([]Case[testInput, testOutput]{
+		{Input: testInput{Value: "a"}},
+	})
+	task := T(func(ctx context.Context, input testInput) (testOutput, error) {
+		return testOutput{Result: input.Value}, nil
+	})
+
+	scorers := []Scorer[testInput, testOutput]{
+		NewScorer("fast", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+			return S(1), nil
+		}),
+		NewScorer("slow", func(ctx context.Context, r TaskResult[testInput, testOutput]) (Scores, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}),
+	}
+
+	ute := newUnitTestEval(t, cases, task, scorers, 1)
+	ute.eval.scorerTimeout = 20 * time.Millisecond
+
+	result, err := ute.eval.run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTimeout)
+	assert.ErrorIs(t, err, errScorer)
+	assert.Contains(t, err.Error(), `scorer "slow" exceeded 20ms`)
+	assert.Equal(t, 1, result.Timeouts())
+
+	fast, ok := result.Score("fast")
+	require.True(t, ok)
+	assert.Equal(t, 1, fast.Count)
+	slow, ok := result.Score("slow")
+	require.True(t, ok)
+	assert.Equal(t, 1, slow.Errors)
+}
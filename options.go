@@ -5,6 +5,7 @@ import (
 
 	"github.com/braintrustdata/braintrust-sdk-go/config"
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // Option is a functional option for configuring a Braintrust client
@@ -95,3 +96,13 @@ func WithSpanFilterFuncs(filterFuncs ...config.SpanFilterFunc) Option {
 		c.SpanFilterFuncs = append(c.SpanFilterFuncs, filterFuncs...)
 	}
 }
+
+// WithRedactor sets a Redactor that masks sensitive data in the input, output, metadata,
+// error messages and events of every span the client exports to Braintrust, whichever
+// integration or tracer recorded it. Spans sent to other exporters of the TracerProvider
+// are not redacted; use the WithRedactor options of the trace/contrib integrations for those.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(c *config.Config) {
+		c.Redactor = r
+	}
+}
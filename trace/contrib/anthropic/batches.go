@@ -0,0 +1,228 @@
+package anthropic
+
+// this file parses the message batches API.
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// maxBatchCustomIDs is the most custom_ids logged on a batch create span.
+const maxBatchCustomIDs = 100
+
+// batchCreateTracer is a tracer for the anthropic v1/messages/batches POST endpoint.
+// See docs here: https://docs.anthropic.com/en/api/creating-message-batches
+type batchCreateTracer struct {
+	cfg      *middlewareConfig
+	metadata map[string]any
+}
+
+func newBatchCreateTracer(cfg *middlewareConfig) *batchCreateTracer {
+	return &batchCreateTracer{
+		cfg: cfg,
+		metadata: map[string]any{
+			"provider": "anthropic",
+			"endpoint": "/v1/messages/batches",
+		},
+	}
+}
+
+func (bt *batchCreateTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := bt.cfg.tracer().Start(
+		ctx,
+		"anthropic.messages.batches.create",
+		trace.WithTimestamp(t),
+	)
+
+	var raw struct {
+		Requests []struct {
+			CustomID string `json:"custom_id"`
+			Params   struct {
+				Model string `json:"model"`
+			} `json:"params"`
+		} `json:"requests"`
+	}
+	if err := json.NewDecoder(request).Decode(&raw); err != nil {
+		return ctx, span, err
+	}
+
+	// A batch can hold many thousands of requests, so only the request count and the first
+	// custom_ids are logged. Each request's input is logged on the span of its result.
+	customIDs := make([]string, 0, min(len(raw.Requests), maxBatchCustomIDs))
+	models := map[string]bool{}
+	for _, r := range raw.Requests {
+		if len(customIDs) < maxBatchCustomIDs {
+			customIDs = append(customIDs, r.CustomID)
+		}
+		if r.Params.Model != "" {
+			models[r.Params.Model] = true
+		}
+	}
+	input := map[string]any{
+		"request_count": len(raw.Requests),
+		"custom_ids":    customIDs,
+	}
+	bt.metadata["request_count"] = len(raw.Requests)
+	if len(models) == 1 {
+		for model := range models {
+			bt.metadata["model"] = model
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.input_json", input); err != nil {
+		return ctx, span, err
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", bt.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+func (bt *batchCreateTracer) TagSpan(span trace.Span, body io.Reader) error {
+	var raw map[string]any
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"id", "processing_status", "expires_at"} {
+		if v, ok := raw[field]; ok {
+			key := field
+			if field == "id" {
+				key = "batch_id"
+			}
+			bt.metadata[key] = v
+		}
+	}
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", bt.metadata); err != nil {
+		return err
+	}
+
+	return internal.SetJSONAttr(span, "braintrust.output_json", raw)
+}
+
+// batchResultsTracer is a tracer for the anthropic v1/messages/batches/{id}/results GET endpoint.
+// Each result gets a child span linking it to the custom_id of its request.
+// See docs here: https://docs.anthropic.com/en/api/retrieving-message-batch-results
+type batchResultsTracer struct {
+	cfg      *middlewareConfig
+	batchID  string
+	metadata map[string]any
+	ctx      context.Context // the context of the results span
+}
+
+func newBatchResultsTracer(cfg *middlewareConfig, batchID string) *batchResultsTracer {
+	return &batchResultsTracer{
+		cfg:     cfg,
+		batchID: batchID,
+		metadata: map[string]any{
+			"provider": "anthropic",
+			"endpoint": "/v1/messages/batches/{message_batch_id}/results",
+			"batch_id": batchID,
+		},
+	}
+}
+
+// TracesBodylessRequests returns true because results are fetched with a GET request.
+func (rt *batchResultsTracer) TracesBodylessRequests() bool {
+	return true
+}
+
+func (rt *batchResultsTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := rt.cfg.tracer().Start(
+		ctx,
+		"anthropic.messages.batches.results",
+		trace.WithTimestamp(t),
+	)
+	rt.ctx = ctx
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", rt.metadata); err != nil {
+		return ctx, span, err
+	}
+	return ctx, span, nil
+}
+
+// batchDiscount scales the cost of batch requests, which are billed at half price.
+const batchDiscount = 0.5
+
+// batchResult is one line of the JSONL results of a message batch.
+type batchResult struct {
+	CustomID string `json:"custom_id"`
+	Result   struct {
+		Type    string         `json:"type"`
+		Message map[string]any `json:"message"`
+		Error   map[string]any `json:"error"`
+	} `json:"result"`
+}
+
+func (rt *batchResultsTracer) TagSpan(span trace.Span, body io.Reader) error {
+	ctx := trace.ContextWithSpan(rt.ctx, span)
+	counts := map[string]int{}
+	var errs []error
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var result batchResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return err
+		}
+		counts[result.Result.Type]++
+		if err := rt.tagResult(ctx, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	rt.metadata["result_counts"] = counts
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", rt.metadata); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// tagResult records a single batch result as a child span of the results span.
+func (rt *batchResultsTracer) tagResult(ctx context.Context, result batchResult) error {
+	ctx, span := rt.cfg.tracer().Start(ctx, "anthropic.messages.batches.result")
+	defer span.End()
+
+	// reuse the messages tracer so succeeded results look like anthropic.messages.create spans
+	mt := newMessagesTracer(rt.cfg)
+	mt.metadata["endpoint"] = rt.metadata["endpoint"]
+	mt.metadata["batch_id"] = rt.batchID
+	mt.metadata["custom_id"] = result.CustomID
+	mt.metadata["result_type"] = result.Result.Type
+
+	switch result.Result.Type {
+	case "succeeded":
+		if err := mt.handleMessageResponse(span, result.Result.Message); err != nil {
+			return err
+		}
+		return internal.RecordCost(ctx, span, batchDiscount)
+	case "errored":
+		msg := "batch request errored"
+		if e, ok := result.Result.Error["error"].(map[string]any); ok {
+			if m, ok := e["message"].(string); ok {
+				msg = m
+			}
+		}
+		span.SetStatus(codes.Error, msg)
+	}
+	return internal.SetJSONAttr(span, "braintrust.metadata", mt.metadata)
+}
@@ -0,0 +1,219 @@
+package anthropic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+)
+
+// setUpFakeServer returns a client traced by the middleware that sends requests to handler.
+func setUpFakeServer(t *testing.T, handler http.HandlerFunc) (anthropic.Client, *oteltest.Exporter) {
+	t.Helper()
+	tp, exporter := oteltest.Setup(t)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := anthropic.NewClient(
+		option.WithBaseURL(server.URL),
+		option.WithAPIKey("test-key"),
+		option.WithMaxRetries(0),
+		option.WithMiddleware(NewMiddleware(WithTracerProvider(tp))),
+	)
+	return client, exporter
+}
+
+func TestCountTokens(t *testing.T) {
+	client, exporter := setUpFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages/count_tokens", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"input_tokens": 14}`)
+	})
+
+	resp, err := client.Messages.CountTokens(context.Background(), anthropic.MessageCountTokensParams{
+		Model: anthropic.Model("claude-3-haiku-20240307"),
+		System: anthropic.MessageCountTokensParamsSystemUnion{
+			OfString: anthropic.String("Be brief."),
+		},
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock("Hello, Claude!")),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), resp.InputTokens)
+
+	span := exporter.FlushOne()
+	span.AssertNameIs("anthropic.messages.countTokens")
+	span.AssertJSONAttrEquals("braintrust.input_json", []any{
+		map[string]any{"role": "system", "content": "Be brief."},
+		map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Hello, Claude!"}}},
+	})
+	span.AssertJSONAttrEquals("braintrust.output_json", map[string]any{"input_tokens": 14.0})
+	span.AssertJSONAttrEquals("braintrust.metrics", map[string]any{"cost": 0.0})
+	span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider": "anthropic",
+		"endpoint": "/v1/messages/count_tokens",
+		"model":    "claude-3-haiku-20240307",
+	})
+}
+
+func TestBatchCreate(t *testing.T) {
+	client, exporter := setUpFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages/batches", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"id": "msgbatch_123",
+			"type": "message_batch",
+			"processing_status": "in_progress",
+			"request_counts": {"processing": 2, "succeeded": 0, "errored": 0, "canceled": 0, "expired": 0},
+			"created_at": "2026-10-16T00:00:00Z",
+			"expires_at": "2026-10-17T00:00:00Z"
+		}`)
+	})
+
+	request := func(id, text string) anthropic.MessageBatchNewParamsRequest {
+		return anthropic.MessageBatchNewParamsRequest{
+			CustomID: id,
+			Params: anthropic.MessageBatchNewParamsRequestParams{
+				Model:     anthropic.Model("claude-3-haiku-20240307"),
+				MaxTokens: 100,
+				Messages:  []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock(text))},
+			},
+		}
+	}
+	batch, err := client.Messages.Batches.New(context.Background(), anthropic.MessageBatchNewParams{
+		Requests: []anthropic.MessageBatchNewParamsRequest{request("first", "One"), request("second", "Two")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "msgbatch_123", batch.ID)
+
+	span := exporter.FlushOne()
+	span.AssertNameIs("anthropic.messages.batches.create")
+	span.AssertJSONAttrEquals("braintrust.input_json", map[string]any{
+		"request_count": 2.0,
+		"custom_ids":    []any{"first", "second"},
+	})
+	span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider":          "anthropic",
+		"endpoint":          "/v1/messages/batches",
+		"model":             "claude-3-haiku-20240307",
+		"request_count":     2.0,
+		"batch_id":          "msgbatch_123",
+		"processing_status": "in_progress",
+		"expires_at":        "2026-10-17T00:00:00Z",
+	})
+}
+
+func TestBatchCreate_CapsCustomIDs(t *testing.T) {
+	client, exporter := setUpFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id": "msgbatch_123", "type": "message_batch", "processing_status": "in_progress"}`)
+	})
+
+	var requests []anthropic.MessageBatchNewParamsRequest
+	var ids []any
+	for i := range maxBatchCustomIDs + 10 {
+		id := fmt.Sprintf("request-%d", i)
+		requests = append(requests, anthropic.MessageBatchNewParamsRequest{
+			CustomID: id,
+			Params: anthropic.MessageBatchNewParamsRequestParams{
+				Model:     anthropic.Model("claude-3-haiku-20240307"),
+				MaxTokens: 100,
+				Messages:  []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock("Hi"))},
+			},
+		})
+		if i < maxBatchCustomIDs {
+			ids = append(ids, id)
+		}
+	}
+	_, err := client.Messages.Batches.New(context.Background(), anthropic.MessageBatchNewParams{Requests: requests})
+	require.NoError(t, err)
+
+	span := exporter.FlushOne()
+	span.AssertJSONAttrEquals("braintrust.input_json", map[string]any{
+		"request_count": float64(maxBatchCustomIDs + 10),
+		"custom_ids":    ids,
+	})
+}
+
+func TestBatchResults(t *testing.T) {
+	client, exporter := setUpFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/messages/batches/msgbatch_123/results", r.URL.Path)
+		w.Header().Set("Content-Type", "application/x-jsonl")
+		_, _ = io.WriteString(w, `{"custom_id":"first","result":{"type":"succeeded","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-3-haiku-20240307","content":[{"type":"text","text":"Uno"}],"stop_reason":"end_turn","usage":{"input_tokens":10,"output_tokens":3}}}}
+{"custom_id":"second","result":{"type":"errored","error":{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens is too large"}}}}
+{"custom_id":"third","result":{"type":"expired"}}
+`)
+	})
+
+	stream := client.Messages.Batches.ResultsStreaming(context.Background(), "msgbatch_123")
+	var ids []string
+	for stream.Next() {
+		ids = append(ids, stream.Current().CustomID)
+	}
+	require.NoError(t, stream.Err())
+	require.NoError(t, stream.Close())
+	assert.Equal(t, []string{"first", "second", "third"}, ids)
+
+	spans := exporter.Flush()
+	require.Len(t, spans, 4)
+
+	var parent oteltest.Span
+	byCustomID := map[string]oteltest.Span{}
+	for _, span := range spans {
+		switch span.Name() {
+		case "anthropic.messages.batches.results":
+			parent = span
+		case "anthropic.messages.batches.result":
+			md := span.Metadata()
+			byCustomID[md["custom_id"].(string)] = span
+		}
+	}
+	require.Len(t, byCustomID, 3)
+
+	parent.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider":      "anthropic",
+		"endpoint":      "/v1/messages/batches/{message_batch_id}/results",
+		"batch_id":      "msgbatch_123",
+		"result_counts": map[string]any{"succeeded": 1.0, "errored": 1.0, "expired": 1.0},
+	})
+	for _, span := range byCustomID {
+		assert.Equal(t, parent.Stub.SpanContext.SpanID(), span.Stub.Parent.SpanID())
+		assert.Equal(t, "msgbatch_123", span.Metadata()["batch_id"])
+	}
+
+	succeeded := byCustomID["first"]
+	succeeded.AssertJSONAttrEquals("braintrust.output_json", []any{
+		map[string]any{"role": "assistant", "content": []any{map[string]any{"type": "text", "text": "Uno"}}},
+	})
+	metrics := succeeded.Metrics()
+	assert.Equal(t, 10.0, metrics["prompt_tokens"])
+	assert.Equal(t, 3.0, metrics["completion_tokens"])
+	assert.InDelta(t, batchDiscount*(10*0.25+3*1.25)/1e6, metrics["cost"], 1e-12)
+	md := succeeded.Metadata()
+	assert.Equal(t, "succeeded", md["result_type"])
+	assert.Equal(t, "end_turn", md["stop_reason"])
+	assert.Equal(t, "claude-3-haiku-20240307", md["model"])
+	assert.Equal(t, codes.Unset, succeeded.Status().Code)
+
+	errored := byCustomID["second"]
+	assert.Equal(t, codes.Error, errored.Status().Code)
+	assert.Equal(t, "max_tokens is too large", errored.Status().Description)
+	assert.Equal(t, "errored", errored.Metadata()["result_type"])
+
+	expired := byCustomID["third"]
+	assert.Equal(t, "expired", expired.Metadata()["result_type"])
+	assert.False(t, expired.HasAttr("braintrust.output_json"))
+}
@@ -0,0 +1,76 @@
+package anthropic
+
+// this file parses the count tokens API.
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// countTokensTracer is a tracer for the anthropic v1/messages/count_tokens POST endpoint.
+// See docs here: https://docs.anthropic.com/en/api/messages-count-tokens
+type countTokensTracer struct {
+	cfg      *middlewareConfig
+	metadata map[string]any
+}
+
+func newCountTokensTracer(cfg *middlewareConfig) *countTokensTracer {
+	return &countTokensTracer{
+		cfg: cfg,
+		metadata: map[string]any{
+			"provider": "anthropic",
+			"endpoint": "/v1/messages/count_tokens",
+		},
+	}
+}
+
+func (ct *countTokensTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := ct.cfg.tracer().Start(
+		ctx,
+		"anthropic.messages.countTokens",
+		trace.WithTimestamp(t),
+	)
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(request).Decode(&raw); err != nil {
+		return ctx, span, err
+	}
+
+	for _, field := range []string{"model", "tools", "tool_choice", "thinking", "mcp_servers"} {
+		if value, exists := raw[field]; exists {
+			ct.metadata[field] = value
+		}
+	}
+
+	if msgs := messagesInput(raw); len(msgs) > 0 {
+		if err := internal.SetJSONAttr(span, "braintrust.input_json", msgs); err != nil {
+			return ctx, span, err
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", ct.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+func (ct *countTokensTracer) TagSpan(span trace.Span, body io.Reader) error {
+	var raw map[string]any
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return err
+	}
+
+	// The count is only recorded in the output as "input_tokens". No tokens were used, so it
+	// isn't a token metric, which would add it to the usage totals, and counting tokens is free.
+	if err := internal.SetJSONAttr(span, "braintrust.output_json", raw); err != nil {
+		return err
+	}
+	return internal.SetJSONAttr(span, "braintrust.metrics", map[string]int64{"cost": 0})
+}
@@ -76,7 +76,22 @@ func (mt *messagesTracer) StartSpan(ctx context.Context, t time.Time, request io
 		}
 	}
 
-	// Build input messages array, prepending system prompt if present
+	if msgs := messagesInput(raw); len(msgs) > 0 {
+		if err := internal.SetJSONAttr(span, "braintrust.input_json", msgs); err != nil {
+			return ctx, span, err
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", mt.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+// messagesInput builds the input messages of a messages request, prepending the system prompt
+// as a message if present.
+func messagesInput(raw map[string]any) []any {
 	var msgs []any
 
 	// Prepend system prompt as a message if present
@@ -91,18 +106,7 @@ func (mt *messagesTracer) StartSpan(ctx context.Context, t time.Time, request io
 	if messages, ok := raw["messages"].([]any); ok {
 		msgs = append(msgs, messages...)
 	}
-
-	if len(msgs) > 0 {
-		if err := internal.SetJSONAttr(span, "braintrust.input_json", msgs); err != nil {
-			return ctx, span, err
-		}
-	}
-
-	if err := internal.SetJSONAttr(span, "braintrust.metadata", mt.metadata); err != nil {
-		return ctx, span, err
-	}
-
-	return ctx, span, nil
+	return msgs
 }
 
 func (mt *messagesTracer) TagSpan(span trace.Span, body io.Reader) error {
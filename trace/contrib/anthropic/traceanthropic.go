@@ -36,12 +36,14 @@ package anthropic
 
 import (
 	"net/http"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
 	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // NextMiddleware represents the next middleware to run in the OpenAI client middleware chain
@@ -51,6 +53,7 @@ type NextMiddleware = internal.NextMiddleware
 type middlewareConfig struct {
 	tracerProvider trace.TracerProvider
 	logger         logger.Logger
+	redactor       *redact.Redactor
 }
 
 // MiddlewareOption configures the middleware
@@ -72,13 +75,23 @@ func WithLogger(log logger.Logger) MiddlewareOption {
 	}
 }
 
+// WithRedactor sets the Redactor that masks the input, output and metadata of the spans
+// created by the middleware when they are recorded, so they are redacted for every exporter.
+// Spans exported by a braintrust.Client set up with braintrust.WithRedactor are also
+// redacted on export without this option. If not provided, spans are not redacted here.
+func WithRedactor(r *redact.Redactor) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.redactor = r
+	}
+}
+
 // tracer returns the configured tracer
 func (c *middlewareConfig) tracer() trace.Tracer {
 	tp := c.tracerProvider
 	if tp == nil {
 		tp = otel.GetTracerProvider()
 	}
-	return tp.Tracer("braintrust")
+	return internal.RedactingTracer(tp.Tracer("braintrust"), c.redactor)
 }
 
 // NewMiddleware creates a new OpenTelemetry tracing middleware for Anthropic client requests.
@@ -103,8 +116,20 @@ func NewMiddleware(opts ...MiddlewareOption) func(*http.Request, NextMiddleware)
 
 // anthropicRouter maps Anthropic API paths to their corresponding tracers.
 func anthropicRouter(cfg *middlewareConfig, path string) internal.MiddlewareTracer {
-	if path == "/v1/messages" {
+	switch path {
+	case "/v1/messages":
 		return newMessagesTracer(cfg)
+	case "/v1/messages/count_tokens":
+		return newCountTokensTracer(cfg)
+	case "/v1/messages/batches":
+		return newBatchCreateTracer(cfg)
+	}
+
+	// /v1/messages/batches/{message_batch_id}/results
+	if id, ok := strings.CutPrefix(path, "/v1/messages/batches/"); ok {
+		if id, ok := strings.CutSuffix(id, "/results"); ok && id != "" && !strings.Contains(id, "/") {
+			return newBatchResultsTracer(cfg, id)
+		}
 	}
 	return nil
 }
@@ -159,3 +184,6 @@ func parseUsageTokens(usage map[string]interface{}) map[string]int64 {
 
 // Ensure our tracers implement the shared interface
 var _ internal.MiddlewareTracer = &messagesTracer{}
+var _ internal.MiddlewareTracer = &countTokensTracer{}
+var _ internal.MiddlewareTracer = &batchCreateTracer{}
+var _ internal.BodylessTracer = &batchResultsTracer{}
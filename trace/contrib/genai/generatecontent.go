@@ -14,18 +14,20 @@ import (
 	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
 )
 
-// generateContentTracer is a tracer for the Gemini generateContent endpoint.
+// generateContentTracer is a tracer for the Gemini generateContent and streamGenerateContent
+// endpoints.
 type generateContentTracer struct {
 	cfg       *config
 	streaming bool
 	metadata  map[string]any
 	model     string
+	startTime time.Time
 }
 
-func newGenerateContentTracer(cfg *config, model string) *generateContentTracer {
+func newGenerateContentTracer(cfg *config, model string, streaming bool) *generateContentTracer {
 	return &generateContentTracer{
 		cfg:       cfg,
-		streaming: false,
+		streaming: streaming,
 		model:     model,
 		metadata: map[string]any{
 			"provider": "gemini",
@@ -34,9 +36,14 @@ func newGenerateContentTracer(cfg *config, model string) *generateContentTracer
 }
 
 func (gt *generateContentTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	gt.startTime = t
+	name := "genai.models.generateContent"
+	if gt.streaming {
+		name = "genai.models.generateContentStream"
+	}
 	ctx, span := gt.cfg.tracer().Start(
 		ctx,
-		"genai.models.generateContent",
+		name,
 		trace.WithTimestamp(t),
 	)
 
@@ -122,8 +129,9 @@ func (gt *generateContentTracer) StartSpan(ctx context.Context, t time.Time, req
 }
 
 func (gt *generateContentTracer) TagSpan(span trace.Span, body io.Reader) error {
-	// For now, handle non-streaming responses
-	// Streaming will be handled separately
+	if gt.streaming {
+		return gt.parseStreamingResponse(span, body)
+	}
 	return gt.parseResponse(span, body)
 }
 
@@ -138,6 +146,11 @@ func (gt *generateContentTracer) parseResponse(span trace.Span, body io.Reader)
 }
 
 func (gt *generateContentTracer) handleResponse(span trace.Span, raw map[string]any) error {
+	return gt.handleResponseMetrics(span, raw, nil)
+}
+
+// handleResponseMetrics tags the span with a complete response, adding extra to the usage metrics.
+func (gt *generateContentTracer) handleResponseMetrics(span trace.Span, raw map[string]any, extra map[string]float64) error {
 	// Extract model version if present
 	if modelVersion, ok := raw["modelVersion"].(string); ok {
 		gt.metadata["model"] = modelVersion
@@ -154,14 +167,18 @@ func (gt *generateContentTracer) handleResponse(span trace.Span, raw map[string]
 	}
 
 	// Parse usage metadata (token counts)
-	if usageMetadata, ok := raw["usageMetadata"].(map[string]any); ok {
-		metrics := parseUsageTokens(usageMetadata)
-		if err := internal.SetJSONAttr(span, "braintrust.metrics", metrics); err != nil {
-			return err
-		}
+	usageMetadata, hasUsage := raw["usageMetadata"].(map[string]any)
+	if !hasUsage && len(extra) == 0 {
+		return nil
 	}
-
-	return nil
+	metrics := map[string]float64{}
+	for k, v := range parseUsageTokens(usageMetadata) {
+		metrics[k] = float64(v)
+	}
+	for k, v := range extra {
+		metrics[k] = v
+	}
+	return internal.SetJSONAttr(span, "braintrust.metrics", metrics)
 }
 
 // parseUsageTokens parses the usage tokens from Gemini API responses
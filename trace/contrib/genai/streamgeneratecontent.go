@@ -0,0 +1,194 @@
+package genai
+
+// this file parses the streamGenerateContent API.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// parseStreamingResponse parses a streamGenerateContent response, which is either a stream of
+// server-sent events (with ?alt=sse, as the genai client sends) or a JSON array of responses.
+// The chunks are merged into a single response, so the span looks like a generateContent call.
+func (gt *generateContentTracer) parseStreamingResponse(span trace.Span, body io.Reader) error {
+	var timeToFirstToken time.Duration
+	merged := newStreamedResponse()
+
+	br := bufio.NewReader(body)
+	first, err := peekNonSpace(br)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if first == '[' {
+		// decode the elements as they arrive, so the first one gives the time to first token
+		dec := json.NewDecoder(br)
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		for dec.More() {
+			var chunk map[string]any
+			if err := dec.Decode(&chunk); err != nil {
+				return err
+			}
+			if timeToFirstToken == 0 {
+				timeToFirstToken = time.Since(gt.startTime)
+			}
+			merged.add(chunk)
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	} else {
+		scanner := bufio.NewScanner(br)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+			if timeToFirstToken == 0 {
+				timeToFirstToken = time.Since(gt.startTime)
+			}
+
+			var chunk map[string]any
+			if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+				return err
+			}
+			merged.add(chunk)
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	return gt.handleResponseMetrics(span, merged.response(), map[string]float64{
+		"time_to_first_token": timeToFirstToken.Seconds(),
+	})
+}
+
+// peekNonSpace skips leading whitespace and returns the next byte without consuming it.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.ContainsAny(b, " \t\r\n") {
+			return b[0], nil
+		}
+		_, _ = br.ReadByte()
+	}
+}
+
+// streamedResponse reassembles streamed generateContent chunks into one response.
+type streamedResponse struct {
+	candidates map[int]map[string]any // by candidate index
+	order      []int
+	last       map[string]any // top level fields of the latest chunk
+}
+
+func newStreamedResponse() *streamedResponse {
+	return &streamedResponse{candidates: map[int]map[string]any{}, last: map[string]any{}}
+}
+
+// add merges a chunk. Text parts are appended to the previous part of the same candidate if it
+// is also text (of the same kind, so thoughts stay separate), and other parts are kept as is.
+// Other candidate fields, such as finishReason, and top level fields such as usageMetadata,
+// take the value of the latest chunk that has them.
+func (s *streamedResponse) add(chunk map[string]any) {
+	for k, v := range chunk {
+		if k != "candidates" {
+			s.last[k] = v
+		}
+	}
+
+	candidates, _ := chunk["candidates"].([]any)
+	for i, c := range candidates {
+		candidate, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		index := i
+		if ok, idx := internal.ToInt64(candidate["index"]); ok {
+			index = int(idx)
+		}
+
+		merged, ok := s.candidates[index]
+		if !ok {
+			merged = map[string]any{}
+			s.candidates[index] = merged
+			s.order = append(s.order, index)
+		}
+
+		for k, v := range candidate {
+			if k != "content" {
+				merged[k] = v
+			}
+		}
+
+		content, ok := candidate["content"].(map[string]any)
+		if !ok {
+			continue
+		}
+		mergedContent, _ := merged["content"].(map[string]any)
+		if mergedContent == nil {
+			mergedContent = map[string]any{}
+			merged["content"] = mergedContent
+		}
+		if role, ok := content["role"]; ok {
+			mergedContent["role"] = role
+		}
+		parts, _ := mergedContent["parts"].([]any)
+		newParts, _ := content["parts"].([]any)
+		for _, p := range newParts {
+			parts = appendPart(parts, p)
+		}
+		mergedContent["parts"] = parts
+	}
+}
+
+// appendPart appends part to parts, merging consecutive text parts.
+func appendPart(parts []any, part any) []any {
+	p, ok := part.(map[string]any)
+	text, isText := p["text"].(string)
+	if !ok || !isText || len(parts) == 0 {
+		return append(parts, part)
+	}
+	prev, ok := parts[len(parts)-1].(map[string]any)
+	if !ok {
+		return append(parts, part)
+	}
+	prevText, prevIsText := prev["text"].(string)
+	if !prevIsText || prev["thought"] != p["thought"] {
+		return append(parts, part)
+	}
+	prev["text"] = prevText + text
+	return parts
+}
+
+// response returns the merged response, with candidates in the order they first appeared.
+func (s *streamedResponse) response() map[string]any {
+	raw := make(map[string]any, len(s.last)+1)
+	for k, v := range s.last {
+		raw[k] = v
+	}
+	if len(s.order) > 0 {
+		candidates := make([]any, 0, len(s.order))
+		for _, index := range s.order {
+			candidates = append(candidates, s.candidates[index])
+		}
+		raw["candidates"] = candidates
+	}
+	return raw
+}
@@ -0,0 +1,152 @@
+package genai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/genai"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+)
+
+const streamedSSE = `data: {"candidates": [{"content": {"role": "model", "parts": [{"text": "The answer"}]}, "index": 0}], "modelVersion": "gemini-2.0-flash"}
+
+data: {"candidates": [{"content": {"role": "model", "parts": [{"text": " is 4."}]}, "index": 0}], "modelVersion": "gemini-2.0-flash"}
+
+data: {"candidates": [{"content": {"role": "model", "parts": [{"text": ""}]}, "finishReason": "STOP", "index": 0}], "usageMetadata": {"promptTokenCount": 8, "candidatesTokenCount": 5, "totalTokenCount": 13}, "modelVersion": "gemini-2.0-flash", "responseId": "resp-1"}
+
+`
+
+func TestStreamGenerateContent(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1beta/models/gemini-2.0-flash:streamGenerateContent", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(streamedSSE))
+	}))
+	defer server.Close()
+
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		HTTPClient:  WrapClient(nil, WithTracerProvider(tp)),
+		APIKey:      "test-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: server.URL},
+	})
+	require.NoError(t, err)
+
+	var text strings.Builder
+	for resp, err := range client.Models.GenerateContentStream(context.Background(), "gemini-2.0-flash", genai.Text("What is 2+2?"), nil) {
+		require.NoError(t, err)
+		text.WriteString(resp.Text())
+	}
+	assert.Equal(t, "The answer is 4.", text.String())
+
+	ts := exporter.FlushOne()
+	ts.AssertNameIs("genai.models.generateContentStream")
+	assert.Equal(t, codes.Unset, ts.Status().Code)
+	ts.AssertJSONAttrEquals("braintrust.output_json", map[string]any{
+		"candidates": []any{map[string]any{
+			"content":      map[string]any{"role": "model", "parts": []any{map[string]any{"text": "The answer is 4."}}},
+			"finishReason": "STOP",
+			"index":        0.0,
+		}},
+		"usageMetadata": map[string]any{"promptTokenCount": 8.0, "candidatesTokenCount": 5.0, "totalTokenCount": 13.0},
+		"modelVersion":  "gemini-2.0-flash",
+		"responseId":    "resp-1",
+	})
+
+	metrics := ts.Metrics()
+	assert.Equal(t, 8.0, metrics["prompt_tokens"])
+	assert.Equal(t, 5.0, metrics["completion_tokens"])
+	assert.Equal(t, 13.0, metrics["tokens"])
+	assert.Greater(t, metrics["time_to_first_token"], 0.0)
+}
+
+func TestStreamGenerateContent_JSONArray(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+	gt := newGenerateContentTracer(&config{tracerProvider: tp}, "gemini-2.0-flash", true)
+
+	_, span, err := gt.StartSpan(context.Background(), time.Now(), strings.NewReader(`{"contents": [{"parts": [{"text": "hi"}]}]}`))
+	require.NoError(t, err)
+	body := ` [
+		{"candidates": [{"content": {"parts": [{"thought": true, "text": "Thinking"}]}},
+		                {"content": {"parts": [{"text": "B1"}]}, "index": 1}]},
+		{"candidates": [{"content": {"parts": [{"text": "Hello"}]}}]},
+		{"candidates": [{"content": {"parts": [{"functionCall": {"name": "f"}}, {"text": " world"}]}, "finishReason": "STOP"},
+		                {"content": {"parts": [{"text": "B2"}]}, "index": 1}],
+		 "usageMetadata": {"promptTokenCount": 1, "candidatesTokenCount": 2, "totalTokenCount": 3}}
+	]`
+	require.NoError(t, gt.TagSpan(span, strings.NewReader(body)))
+	span.End()
+
+	ts := exporter.FlushOne()
+	ts.AssertJSONAttrEquals("braintrust.output_json", map[string]any{
+		"candidates": []any{
+			map[string]any{
+				"content": map[string]any{"parts": []any{
+					map[string]any{"thought": true, "text": "Thinking"},
+					map[string]any{"text": "Hello"},
+					map[string]any{"functionCall": map[string]any{"name": "f"}},
+					map[string]any{"text": " world"},
+				}},
+				"finishReason": "STOP",
+			},
+			map[string]any{
+				"content": map[string]any{"parts": []any{map[string]any{"text": "B1B2"}}},
+				"index":   1.0,
+			},
+		},
+		"usageMetadata": map[string]any{"promptTokenCount": 1.0, "candidatesTokenCount": 2.0, "totalTokenCount": 3.0},
+	})
+	assert.Equal(t, 3.0, ts.Metrics()["tokens"])
+	assert.Contains(t, ts.Metrics(), "time_to_first_token")
+}
+
+func TestStreamGenerateContent_JSONArrayTimeToFirstToken(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+	gt := newGenerateContentTracer(&config{tracerProvider: tp}, "gemini-2.0-flash", true)
+
+	_, span, err := gt.StartSpan(context.Background(), time.Now(), strings.NewReader(`{"contents": [{"parts": [{"text": "hi"}]}]}`))
+	require.NoError(t, err)
+
+	// the rest of the array arrives well after the first element
+	const delay = 200 * time.Millisecond
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte(`[{"candidates": [{"content": {"parts": [{"text": "Hello"}]}}]},`))
+		time.Sleep(delay)
+		_, _ = pw.Write([]byte(`{"candidates": [{"content": {"parts": [{"text": " world"}]}}]}]`))
+		_ = pw.Close()
+	}()
+	require.NoError(t, gt.TagSpan(span, pr))
+	span.End()
+
+	ts := exporter.FlushOne()
+	ttft := ts.Metrics()["time_to_first_token"]
+	assert.Greater(t, ttft, 0.0)
+	assert.Less(t, ttft, delay.Seconds())
+}
+
+func TestGenaiRouter(t *testing.T) {
+	cfg := &config{}
+
+	gt, ok := genaiRouter(cfg, "/v1beta/models/gemini-2.0-flash:streamGenerateContent").(*generateContentTracer)
+	require.True(t, ok)
+	assert.True(t, gt.streaming)
+	assert.Equal(t, "gemini-2.0-flash", gt.model)
+
+	gt, ok = genaiRouter(cfg, "/v1/projects/p/locations/l/publishers/google/models/gemini-2.0-flash:generateContent").(*generateContentTracer)
+	require.True(t, ok)
+	assert.False(t, gt.streaming)
+	assert.Equal(t, "gemini-2.0-flash", gt.model)
+
+	assert.Nil(t, genaiRouter(cfg, "/v1beta/models/gemini-2.0-flash:countTokens"))
+}
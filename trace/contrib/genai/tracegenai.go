@@ -39,12 +39,14 @@ import (
 
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
 	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // config holds configuration for the HTTP client wrapper
 type config struct {
 	tracerProvider trace.TracerProvider
 	logger         logger.Logger
+	redactor       *redact.Redactor
 }
 
 // Option configures the genai HTTP client wrapper
@@ -66,13 +68,23 @@ func WithLogger(log logger.Logger) Option {
 	}
 }
 
+// WithRedactor sets the Redactor that masks the input, output and metadata of the spans
+// created by the HTTP client wrapper when they are recorded, so they are redacted for every exporter.
+// Spans exported by a braintrust.Client set up with braintrust.WithRedactor are also
+// redacted on export without this option. If not provided, spans are not redacted here.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(c *config) {
+		c.redactor = r
+	}
+}
+
 // tracer returns the configured tracer
 func (c *config) tracer() trace.Tracer {
 	tp := c.tracerProvider
 	if tp == nil {
 		tp = otel.GetTracerProvider()
 	}
-	return tp.Tracer("braintrust")
+	return internal.RedactingTracer(tp.Tracer("braintrust"), c.redactor)
 }
 
 // Client returns a new http.Client configured with tracing middleware.
@@ -144,13 +156,23 @@ func genaiRouter(cfg *config, path string) internal.MiddlewareTracer {
 	// Match both Gemini API and Vertex AI paths
 	// Gemini API: /v1beta/models/{model}/generateContent
 	// Vertex AI: /v1/projects/{project}/locations/{location}/publishers/google/models/{model}:generateContent
+	// Streaming calls use :streamGenerateContent instead.
+	if containsStreamGenerateContent(path) {
+		return newGenerateContentTracer(cfg, extractModelFromPath(path), true)
+	}
 	if containsGenerateContent(path) {
 		model := extractModelFromPath(path)
-		return newGenerateContentTracer(cfg, model)
+		return newGenerateContentTracer(cfg, model, false)
 	}
 	return nil
 }
 
+// containsStreamGenerateContent checks if the path is for a streamGenerateContent endpoint
+func containsStreamGenerateContent(path string) bool {
+	return strings.Contains(path, "/streamGenerateContent") ||
+		strings.Contains(path, ":streamGenerateContent")
+}
+
 // containsGenerateContent checks if the path is for a generateContent endpoint
 func containsGenerateContent(path string) bool {
 	return strings.Contains(path, "/generateContent") ||
@@ -170,9 +192,10 @@ func extractModelFromPath(path string) string {
 	// Get the model part (after /models/)
 	modelPart := parts[1]
 
-	// Remove :generateContent or /generateContent suffix
-	modelPart = strings.TrimSuffix(modelPart, ":generateContent")
-	modelPart = strings.TrimSuffix(modelPart, "/generateContent")
+	// Remove :generateContent or /generateContent suffix, or their streaming versions
+	for _, suffix := range []string{":generateContent", "/generateContent", ":streamGenerateContent", "/streamGenerateContent"} {
+		modelPart = strings.TrimSuffix(modelPart, suffix)
+	}
 
 	return modelPart
 }
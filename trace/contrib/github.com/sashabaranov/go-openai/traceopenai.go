@@ -45,12 +45,14 @@ import (
 
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
 	"github.com/braintrustdata/braintrust-sdk-go/trace/contrib/openai"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // config holds configuration for the HTTP client wrapper
 type config struct {
 	tracerProvider trace.TracerProvider
 	logger         logger.Logger
+	redactor       *redact.Redactor
 }
 
 // Option configures the HTTP client wrapper
@@ -72,6 +74,16 @@ func WithLogger(log logger.Logger) Option {
 	}
 }
 
+// WithRedactor sets the Redactor that masks the input, output and metadata of the spans
+// created by the HTTP client wrapper when they are recorded, so they are redacted for every exporter.
+// Spans exported by a braintrust.Client set up with braintrust.WithRedactor are also
+// redacted on export without this option. If not provided, spans are not redacted here.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(c *config) {
+		c.redactor = r
+	}
+}
+
 // Client returns a new http.Client configured with tracing middleware.
 // This is equivalent to WrapClient(nil), which wraps the default HTTP transport.
 //
@@ -135,6 +147,9 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	if rt.cfg.logger != nil {
 		middlewareOpts = append(middlewareOpts, openai.WithLogger(rt.cfg.logger))
 	}
+	if rt.cfg.redactor != nil {
+		middlewareOpts = append(middlewareOpts, openai.WithRedactor(rt.cfg.redactor))
+	}
 
 	// Use the existing openai middleware
 	middleware := openai.NewMiddleware(middlewareOpts...)
@@ -55,6 +55,7 @@ import (
 	"github.com/tmc/langchaingo/schema"
 
 	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // spanEntry represents a span with its type in the stack
@@ -98,6 +99,12 @@ type HandlerOptions struct {
 	// TracerProvider is an optional custom TracerProvider for testing or custom configurations.
 	// If not provided, the global otel.GetTracerProvider() is used.
 	TracerProvider trace.TracerProvider
+
+	// Redactor masks the input, output and metadata of the handler's spans when they are
+	// recorded, so they are redacted for every exporter. Spans exported by a braintrust.Client
+	// set up with braintrust.WithRedactor are also redacted on export without this option.
+	// If not provided, spans are not redacted here.
+	Redactor *redact.Redactor
 }
 
 // NewHandler creates a new Handler for tracing LangChainGo operations.
@@ -179,7 +186,7 @@ func (h *Handler) tracer() trace.Tracer {
 	if tp == nil {
 		tp = otel.GetTracerProvider()
 	}
-	return tp.Tracer("braintrust")
+	return internal.RedactingTracer(tp.Tracer("braintrust"), h.opts.Redactor)
 }
 
 // HandleLLMStart is called at the start of an LLM call with simple string prompts.
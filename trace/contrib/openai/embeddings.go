@@ -0,0 +1,118 @@
+package openai
+
+// this file parses the embeddings API.
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// embeddingsTracer is a tracer for the openai v1/embeddings POST endpoint.
+// See docs here: https://platform.openai.com/docs/api-reference/embeddings/create
+type embeddingsTracer struct {
+	cfg      *middlewareConfig
+	metadata map[string]any
+}
+
+func newEmbeddingsTracer(cfg *middlewareConfig) *embeddingsTracer {
+	return &embeddingsTracer{
+		cfg: cfg,
+		metadata: map[string]any{
+			"provider": "openai",
+			"endpoint": "/v1/embeddings",
+		},
+	}
+}
+
+func (et *embeddingsTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := et.cfg.tracer().Start(
+		ctx,
+		"Embedding",
+		trace.WithTimestamp(t),
+	)
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(request).Decode(&raw); err != nil {
+		return ctx, span, err
+	}
+
+	for _, field := range []string{"model", "dimensions", "encoding_format", "user"} {
+		if value, exists := raw[field]; exists {
+			et.metadata[field] = value
+		}
+	}
+
+	if input, ok := raw["input"]; ok {
+		if err := internal.SetJSONAttr(span, "braintrust.input_json", input); err != nil {
+			return ctx, span, err
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", et.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.span_attributes", llmSpanAttrs); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+func (et *embeddingsTracer) TagSpan(span trace.Span, body io.Reader) error {
+	var raw struct {
+		Model string `json:"model"`
+		Data  []struct {
+			Index     int             `json:"index"`
+			Embedding json.RawMessage `json:"embedding"`
+		} `json:"data"`
+		Usage map[string]any `json:"usage"`
+	}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Model != "" {
+		et.metadata["model"] = raw.Model
+	}
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", et.metadata); err != nil {
+		return err
+	}
+
+	if raw.Usage != nil {
+		if err := internal.SetJSONAttr(span, "braintrust.metrics", parseUsageTokens(raw.Usage)); err != nil {
+			return err
+		}
+	}
+
+	// The vectors are large and not useful to read, so only log their size.
+	output := make([]map[string]any, 0, len(raw.Data))
+	for _, d := range raw.Data {
+		output = append(output, map[string]any{
+			"index":      d.Index,
+			"dimensions": embeddingDimensions(d.Embedding),
+		})
+	}
+	return internal.SetJSONAttr(span, "braintrust.output_json", output)
+}
+
+// embeddingDimensions returns the length of an embedding, which is either an array of floats
+// or a base64 string of float32s when encoding_format is "base64". It returns 0 if it can't tell.
+func embeddingDimensions(embedding json.RawMessage) int {
+	var floats []float64
+	if err := json.Unmarshal(embedding, &floats); err == nil {
+		return len(floats)
+	}
+	var encoded string
+	if err := json.Unmarshal(embedding, &encoded); err == nil {
+		return base64.StdEncoding.DecodedLen(len(encoded)) / 4
+	}
+	return 0
+}
@@ -0,0 +1,224 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
+)
+
+// setUpFakeServer returns an openai client traced by the middleware that sends requests to
+// a server replying with body, and the exporter receiving its spans.
+func setUpFakeServer(t *testing.T, path string, contentType string, body string, opts ...MiddlewareOption) (openai.Client, *oteltest.Exporter) {
+	t.Helper()
+
+	tp, exporter := oteltest.Setup(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := openai.NewClient(
+		option.WithAPIKey("test-key"),
+		option.WithBaseURL(server.URL+"/v1/"),
+		option.WithMiddleware(NewMiddleware(append(opts, WithTracerProvider(tp))...)), //nolint:bodyclose // false positive - NewMiddleware returns middleware func
+	)
+	return client, exporter
+}
+
+func TestEmbeddings(t *testing.T) {
+	client, exporter := setUpFakeServer(t, "/v1/embeddings", "application/json", `{
+		"object": "list",
+		"model": "text-embedding-3-small",
+		"data": [
+			{"object": "embedding", "index": 0, "embedding": [0.1, 0.2, 0.3]},
+			{"object": "embedding", "index": 1, "embedding": [0.4, 0.5, 0.6]}
+		],
+		"usage": {"prompt_tokens": 8, "total_tokens": 8}
+	}`)
+
+	resp, err := client.Embeddings.New(context.Background(), openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModelTextEmbedding3Small,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: []string{"hello", "world"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+
+	span := exporter.FlushOne()
+	assert.Equal(t, "Embedding", span.Name())
+	span.AssertJSONAttrEquals("braintrust.span_attributes", map[string]any{"type": "llm"})
+	span.AssertJSONAttrEquals("braintrust.input_json", []any{"hello", "world"})
+	span.AssertJSONAttrEquals("braintrust.output_json", []any{
+		map[string]any{"index": 0.0, "dimensions": 3.0},
+		map[string]any{"index": 1.0, "dimensions": 3.0},
+	})
+	metrics := span.Metrics()
+	assert.InDelta(t, 8*0.02/1e6, metrics["cost"], 1e-12)
+	delete(metrics, "cost")
+	assert.Equal(t, map[string]float64{"prompt_tokens": 8, "tokens": 8}, metrics)
+	span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider": "openai",
+		"endpoint": "/v1/embeddings",
+		"model":    "text-embedding-3-small",
+	})
+}
+
+func TestEmbeddings_Redacted(t *testing.T) {
+	dropper, err := redact.DropPaths("output[*].dimensions")
+	require.NoError(t, err)
+	redactor := redact.New(append(redact.PII(), dropper)...)
+	client, exporter := setUpFakeServer(t, "/v1/embeddings", "application/json", `{
+		"object": "list",
+		"model": "text-embedding-3-small",
+		"data": [
+			{"object": "embedding", "index": 0, "embedding": [0.1, 0.2, 0.3]},
+			{"object": "embedding", "index": 1, "embedding": [0.4, 0.5, 0.6]}
+		],
+		"usage": {"prompt_tokens": 8, "total_tokens": 8}
+	}`, WithRedactor(redactor))
+
+	_, err = client.Embeddings.New(context.Background(), openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModelTextEmbedding3Small,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: []string{
+			"mail jane.doe@example.com",
+			"card 4111 1111 1111 1111, call +1 415-555-0100",
+		}},
+	})
+	require.NoError(t, err)
+
+	span := exporter.FlushOne()
+	span.AssertJSONAttrEquals("braintrust.input_json", []any{
+		"mail [EMAIL]",
+		"card [CREDIT_CARD], call [PHONE]",
+	})
+	span.AssertJSONAttrEquals("braintrust.output_json", []any{
+		map[string]any{"index": 0.0},
+		map[string]any{"index": 1.0},
+	})
+	assert.Contains(t, span.Metrics(), "cost", "metrics aren't redacted")
+}
+
+func TestEmbeddingDimensions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3, embeddingDimensions(json.RawMessage(`[1, 2, 3]`)))
+	// four float32s
+	assert.Equal(t, 4, embeddingDimensions(json.RawMessage(`"AAAAAAAAAAAAAAAAAAAAAA=="`)))
+	assert.Equal(t, 0, embeddingDimensions(json.RawMessage(`{}`)))
+}
+
+func TestModerations(t *testing.T) {
+	client, exporter := setUpFakeServer(t, "/v1/moderations", "application/json", `{
+		"id": "modr-123",
+		"model": "omni-moderation-latest",
+		"results": [{"flagged": true, "categories": {"violence": true}, "category_scores": {"violence": 0.9}}]
+	}`)
+
+	resp, err := client.Moderations.New(context.Background(), openai.ModerationNewParams{
+		Model: openai.ModerationModelOmniModerationLatest,
+		Input: openai.ModerationNewParamsInputUnion{OfString: openai.String("I will hurt you")},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+
+	span := exporter.FlushOne()
+	assert.Equal(t, "Moderation", span.Name())
+	span.AssertJSONAttrEquals("braintrust.span_attributes", map[string]any{"type": "llm"})
+	span.AssertJSONAttrEquals("braintrust.input_json", "I will hurt you")
+	span.AssertJSONAttrEquals("braintrust.output_json", []any{map[string]any{
+		"flagged":         true,
+		"categories":      map[string]any{"violence": true},
+		"category_scores": map[string]any{"violence": 0.9},
+	}})
+	span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider": "openai",
+		"endpoint": "/v1/moderations",
+		"model":    "omni-moderation-latest",
+		"id":       "modr-123",
+	})
+}
+
+func TestImageGeneration(t *testing.T) {
+	b64 := strings.Repeat("A", 4000)
+	client, exporter := setUpFakeServer(t, "/v1/images/generations", "application/json", `{
+		"created": 1700000000,
+		"data": [{"b64_json": "`+b64+`", "revised_prompt": "a red cat"}],
+		"usage": {"input_tokens": 10, "output_tokens": 100, "total_tokens": 110,
+			"input_tokens_details": {"text_tokens": 10, "image_tokens": 0}}
+	}`)
+
+	resp, err := client.Images.Generate(context.Background(), openai.ImageGenerateParams{
+		Model:  openai.ImageModelGPTImage1,
+		Prompt: "a cat",
+		Size:   openai.ImageGenerateParamsSize1024x1024,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+
+	span := exporter.FlushOne()
+	assert.Equal(t, "Image Generation", span.Name())
+	span.AssertJSONAttrEquals("braintrust.span_attributes", map[string]any{"type": "llm"})
+	span.AssertJSONAttrEquals("braintrust.input_json", "a cat")
+	span.AssertJSONAttrEquals("braintrust.output_json", []any{map[string]any{
+		"b64_json":       "<3000 bytes>",
+		"revised_prompt": "a red cat",
+	}})
+	metrics := span.Metrics()
+	assert.InDelta(t, (10*5+100*40)/1e6, metrics["cost"], 1e-12)
+	delete(metrics, "cost")
+	assert.Equal(t, map[string]float64{
+		"prompt_tokens":       10,
+		"completion_tokens":   100,
+		"tokens":              110,
+		"prompt_text_tokens":  10,
+		"prompt_image_tokens": 0,
+	}, metrics)
+	span.AssertJSONAttrEquals("braintrust.metadata", map[string]any{
+		"provider": "openai",
+		"endpoint": "/v1/images/generations",
+		"model":    "gpt-image-1",
+		"size":     "1024x1024",
+		"created":  1700000000.0,
+	})
+}
+
+func TestImageGenerationStreaming(t *testing.T) {
+	client, exporter := setUpFakeServer(t, "/v1/images/generations", "text/event-stream",
+		"event: image_generation.partial_image\n"+
+			`data: {"type": "image_generation.partial_image", "b64_json": "AAAA", "partial_image_index": 0}`+"\n\n"+
+			"event: image_generation.completed\n"+
+			`data: {"type": "image_generation.completed", "b64_json": "AAAAAAAA", "created_at": 1700000000, "size": "1024x1024",`+
+			` "usage": {"input_tokens": 10, "output_tokens": 100, "total_tokens": 110}}`+"\n\n")
+
+	stream := client.Images.GenerateStreaming(context.Background(), openai.ImageGenerateParams{
+		Model:         openai.ImageModelGPTImage1,
+		Prompt:        "a cat",
+		PartialImages: openai.Int(1),
+	})
+	events := 0
+	for stream.Next() {
+		events++
+	}
+	require.NoError(t, stream.Err())
+	require.NoError(t, stream.Close())
+	assert.Equal(t, 2, events)
+
+	span := exporter.FlushOne()
+	span.AssertJSONAttrEquals("braintrust.output_json", []any{map[string]any{"b64_json": "<6 bytes>"}})
+	metrics := span.Metrics()
+	assert.InDelta(t, (10*5+100*40)/1e6, metrics["cost"], 1e-12)
+	delete(metrics, "cost")
+	assert.Equal(t, map[string]float64{"prompt_tokens": 10, "completion_tokens": 100, "tokens": 110}, metrics)
+}
@@ -0,0 +1,177 @@
+package openai
+
+// this file parses the image generation API.
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// imagesTracer is a tracer for the openai v1/images/generations POST endpoint.
+// See docs here: https://platform.openai.com/docs/api-reference/images/create
+type imagesTracer struct {
+	cfg       *middlewareConfig
+	streaming bool
+	metadata  map[string]any
+}
+
+func newImagesTracer(cfg *middlewareConfig) *imagesTracer {
+	return &imagesTracer{
+		cfg: cfg,
+		metadata: map[string]any{
+			"provider": "openai",
+			"endpoint": "/v1/images/generations",
+		},
+	}
+}
+
+func (it *imagesTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := it.cfg.tracer().Start(
+		ctx,
+		"Image Generation",
+		trace.WithTimestamp(t),
+	)
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(request).Decode(&raw); err != nil {
+		return ctx, span, err
+	}
+
+	metadataFields := []string{
+		"model",
+		"n",
+		"size",
+		"quality",
+		"style",
+		"background",
+		"moderation",
+		"output_format",
+		"output_compression",
+		"response_format",
+		"partial_images",
+		"stream",
+		"user",
+	}
+	for _, field := range metadataFields {
+		if value, exists := raw[field]; exists {
+			it.metadata[field] = value
+			if field == "stream" {
+				if value, ok := value.(bool); ok {
+					it.streaming = value
+				}
+			}
+		}
+	}
+
+	if prompt, ok := raw["prompt"]; ok {
+		if err := internal.SetJSONAttr(span, "braintrust.input_json", prompt); err != nil {
+			return ctx, span, err
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", it.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.span_attributes", llmSpanAttrs); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+func (it *imagesTracer) TagSpan(span trace.Span, body io.Reader) error {
+	if it.streaming {
+		return it.parseStreamingResponse(span, body)
+	}
+
+	var raw map[string]any
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return err
+	}
+
+	var images []map[string]any
+	if data, ok := raw["data"].([]any); ok {
+		for _, d := range data {
+			if image, ok := d.(map[string]any); ok {
+				images = append(images, summarizeImage(image))
+			}
+		}
+	}
+	return it.tagResult(span, raw, images)
+}
+
+// parseStreamingResponse reads the image_generation.completed event of a streamed response.
+// Partial images are not logged.
+func (it *imagesTracer) parseStreamingResponse(span trace.Span, body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	// each event carries a whole base64 image
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		var event map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			return err
+		}
+		if event["type"] == "image_generation.completed" {
+			if err := it.tagResult(span, event, []map[string]any{summarizeImage(event)}); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// tagResult sets the metadata, usage metrics and summarized images of a response.
+func (it *imagesTracer) tagResult(span trace.Span, raw map[string]any, images []map[string]any) error {
+	for _, field := range []string{"created", "created_at", "background", "output_format", "quality", "size"} {
+		if v, ok := raw[field]; ok {
+			it.metadata[field] = v
+		}
+	}
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", it.metadata); err != nil {
+		return err
+	}
+
+	if usage, ok := raw["usage"].(map[string]any); ok {
+		if err := internal.SetJSONAttr(span, "braintrust.metrics", parseUsageTokens(usage)); err != nil {
+			return err
+		}
+	}
+
+	if images != nil {
+		if err := internal.SetJSONAttr(span, "braintrust.output_json", images); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarizeImage returns the url and revised prompt of a generated image, replacing
+// base64 image data with its size so it doesn't bloat the span.
+func summarizeImage(image map[string]any) map[string]any {
+	summary := map[string]any{}
+	for _, field := range []string{"url", "revised_prompt"} {
+		if v, ok := image[field]; ok {
+			summary[field] = v
+		}
+	}
+	if b64, ok := image["b64_json"].(string); ok {
+		summary["b64_json"] = fmt.Sprintf("<%d bytes>", base64.StdEncoding.DecodedLen(len(b64)))
+	}
+	return summary
+}
@@ -0,0 +1,88 @@
+package openai
+
+// this file parses the moderations API.
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+)
+
+// moderationsTracer is a tracer for the openai v1/moderations POST endpoint.
+// See docs here: https://platform.openai.com/docs/api-reference/moderations/create
+type moderationsTracer struct {
+	cfg      *middlewareConfig
+	metadata map[string]any
+}
+
+func newModerationsTracer(cfg *middlewareConfig) *moderationsTracer {
+	return &moderationsTracer{
+		cfg: cfg,
+		metadata: map[string]any{
+			"provider": "openai",
+			"endpoint": "/v1/moderations",
+		},
+	}
+}
+
+func (mt *moderationsTracer) StartSpan(ctx context.Context, t time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := mt.cfg.tracer().Start(
+		ctx,
+		"Moderation",
+		trace.WithTimestamp(t),
+	)
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(request).Decode(&raw); err != nil {
+		return ctx, span, err
+	}
+
+	if model, ok := raw["model"]; ok {
+		mt.metadata["model"] = model
+	}
+
+	if input, ok := raw["input"]; ok {
+		if err := internal.SetJSONAttr(span, "braintrust.input_json", input); err != nil {
+			return ctx, span, err
+		}
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", mt.metadata); err != nil {
+		return ctx, span, err
+	}
+
+	if err := internal.SetJSONAttr(span, "braintrust.span_attributes", llmSpanAttrs); err != nil {
+		return ctx, span, err
+	}
+
+	return ctx, span, nil
+}
+
+func (mt *moderationsTracer) TagSpan(span trace.Span, body io.Reader) error {
+	var raw map[string]interface{}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"id", "model"} {
+		if v, ok := raw[field]; ok {
+			mt.metadata[field] = v
+		}
+	}
+	if err := internal.SetJSONAttr(span, "braintrust.metadata", mt.metadata); err != nil {
+		return err
+	}
+
+	// moderations don't report token usage
+	if results, ok := raw["results"]; ok {
+		if err := internal.SetJSONAttr(span, "braintrust.output_json", results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ import (
 
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
 	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // NextMiddleware represents the next middleware to run in the OpenAI client middleware chain.
@@ -51,6 +52,7 @@ type NextMiddleware = internal.NextMiddleware
 type middlewareConfig struct {
 	tracerProvider trace.TracerProvider
 	logger         logger.Logger
+	redactor       *redact.Redactor
 }
 
 // MiddlewareOption configures the middleware
@@ -72,13 +74,23 @@ func WithLogger(log logger.Logger) MiddlewareOption {
 	}
 }
 
+// WithRedactor sets the Redactor that masks the input, output and metadata of the spans
+// created by the middleware when they are recorded, so they are redacted for every exporter.
+// Spans exported by a braintrust.Client set up with braintrust.WithRedactor are also
+// redacted on export without this option. If not provided, spans are not redacted here.
+func WithRedactor(r *redact.Redactor) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.redactor = r
+	}
+}
+
 // tracer returns the configured tracer
 func (c *middlewareConfig) tracer() trace.Tracer {
 	tp := c.tracerProvider
 	if tp == nil {
 		tp = otel.GetTracerProvider()
 	}
-	return tp.Tracer("braintrust")
+	return internal.RedactingTracer(tp.Tracer("braintrust"), c.redactor)
 }
 
 // NewMiddleware creates a new OpenTelemetry tracing middleware for OpenAI client requests.
@@ -116,6 +128,18 @@ func openaiRouter(cfg *middlewareConfig, path string) internal.MiddlewareTracer
 		return newResponsesTracer(cfg)
 	}
 
+	if strings.HasSuffix(path, "/v1/embeddings") {
+		return newEmbeddingsTracer(cfg)
+	}
+
+	if strings.HasSuffix(path, "/v1/moderations") {
+		return newModerationsTracer(cfg)
+	}
+
+	if strings.HasSuffix(path, "/v1/images/generations") {
+		return newImagesTracer(cfg)
+	}
+
 	return nil
 }
 
@@ -171,6 +195,12 @@ func translateMetricPrefix(prefix string) string {
 	}
 }
 
+// llmSpanAttrs marks a span as an LLM call in the Braintrust UI.
+var llmSpanAttrs = map[string]string{"type": "llm"}
+
 // Ensure our tracers implement the shared interface
 var _ internal.MiddlewareTracer = &responsesTracer{}
 var _ internal.MiddlewareTracer = &chatCompletionsTracer{}
+var _ internal.MiddlewareTracer = &embeddingsTracer{}
+var _ internal.MiddlewareTracer = &moderationsTracer{}
+var _ internal.MiddlewareTracer = &imagesTracer{}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/pricing"
+)
+
+// RecordCost adds a "cost" metric to the metrics of span, estimated from the model in its
+// metadata and its token metrics, and adds the cost to the trackers of ctx. The estimate is
+// multiplied by scale, e.g. for calls billed at a discount.
+//
+// A cost metric already set by the tracer is kept as is. Spans whose model has no price,
+// and spans that aren't recorded by the OpenTelemetry SDK, are left untouched.
+func RecordCost(ctx context.Context, span trace.Span, scale float64) error {
+	ro, ok := unwrapSpan(span).(sdktrace.ReadOnlySpan)
+	if !ok {
+		return nil
+	}
+
+	var metadata map[string]any
+	var metrics map[string]float64
+	for _, kv := range ro.Attributes() {
+		var err error
+		switch kv.Key {
+		case "braintrust.metadata":
+			err = json.Unmarshal([]byte(kv.Value.AsString()), &metadata)
+		case "braintrust.metrics":
+			err = json.Unmarshal([]byte(kv.Value.AsString()), &metrics)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if cost, ok := metrics["cost"]; ok {
+		pricing.Track(ctx, cost)
+		return nil
+	}
+	model, _ := metadata["model"].(string)
+	cost, ok := pricing.Cost(model, metrics)
+	if !ok {
+		return nil
+	}
+	cost *= scale
+	metrics["cost"] = cost
+	pricing.Track(ctx, cost)
+	return SetJSONAttr(span, "braintrust.metrics", metrics)
+}
@@ -20,6 +20,14 @@ type MiddlewareTracer interface {
 	TagSpan(span trace.Span, response io.Reader) error
 }
 
+// BodylessTracer is implemented by MiddlewareTracers of endpoints whose requests have no body,
+// such as GET requests. Requests without a body are only traced if their tracer implements
+// BodylessTracer, and its StartSpan is passed an empty reader.
+type BodylessTracer interface {
+	MiddlewareTracer
+	TracesBodylessRequests() bool
+}
+
 // NextMiddleware represents the next middleware to run in the client middleware chain.
 type NextMiddleware = func(req *http.Request) (*http.Response, error)
 
@@ -44,31 +52,54 @@ func Middleware(getMiddlewareTracer TracerRouter, log logger.Logger) func(*http.
 			mt = getMiddlewareTracer(req.URL.Path)
 		}
 
-		// Right now we don't bother tracing requests with a nil body, because they have no data.
-		// If needed, we could change that but handle the nil body sanely.
-		if mt == nil || req.Body == nil {
+		if mt == nil {
 			// Some endpoints aren't traced. Just pass them along.
 			return next(req)
 		}
 
-		// Supported endpoint, let's set up tracing.
-		var buf bytes.Buffer
-		reqBody := req.Body
-		defer func() {
-			_ = reqBody.Close() // Ignore error
-		}()
+		// Requests with a nil body have no data, so they're only traced by tracers that
+		// ask for them. Their body is left nil.
+		var ctx context.Context
+		var span trace.Span
+		if req.Body == nil {
+			bt, ok := mt.(BodylessTracer)
+			if !ok || !bt.TracesBodylessRequests() {
+				return next(req)
+			}
+			var err error
+			ctx, span, err = mt.StartSpan(req.Context(), start, http.NoBody)
+			if err != nil {
+				log.Warn("Error starting span", "error", err)
+			}
+		} else {
+			// Supported endpoint, let's set up tracing.
+			var buf bytes.Buffer
+			reqBody := req.Body
+			defer func() {
+				_ = reqBody.Close() // Ignore error
+			}()
+
+			// Use TeeReader - as the tracer reads from tee, it will populate buf
+			tee := io.TeeReader(reqBody, &buf)
+
+			var err error
+			ctx, span, err = mt.StartSpan(req.Context(), start, tee)
+			if err != nil {
+				// Ignore span creation errors - we'll just not trace this request
+				log.Warn("Error starting span", "error", err)
+			}
 
-		// Use TeeReader - as the tracer reads from tee, it will populate buf
-		tee := io.TeeReader(reqBody, &buf)
+			// The tracer may stop reading early (e.g. a JSON decoder before a trailing newline,
+			// or on a malformed body), so read the rest of the body to send it all. Otherwise the
+			// request would be sent truncated, and fail to match its Content-Length.
+			if _, err := io.Copy(io.Discard, tee); err != nil {
+				span.End()
+				return nil, err
+			}
 
-		ctx, span, err := mt.StartSpan(req.Context(), start, tee)
-		if err != nil {
-			// Ignore span creation errors - we'll just not trace this request
-			log.Warn("Error starting span", "error", err)
+			// After tracer has read from tee, set request body to read from buffer
+			req.Body = io.NopCloser(&buf)
 		}
-
-		// After tracer has read from tee, set request body to read from buffer
-		req.Body = io.NopCloser(&buf)
 		req = req.WithContext(ctx)
 
 		// Continue processing the request.
@@ -91,6 +122,9 @@ func Middleware(getMiddlewareTracer TracerRouter, log logger.Logger) func(*http.
 			if err := mt.TagSpan(span, r); err != nil {
 				log.Warn("Error tagging span", "error", err)
 			}
+			if err := RecordCost(ctx, span, 1); err != nil {
+				log.Warn("Error recording cost", "error", err)
+			}
 			span.End(trace.WithTimestamp(now))
 		}
 		body := NewBufferedReader(resp.Body, onResponseDone)
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"runtime/debug"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
+)
+
+// redactedAttrs maps the span attributes holding payloads to the field they're redacted as.
+var redactedAttrs = map[attribute.Key]string{
+	"braintrust.input":       redact.FieldInput,
+	"braintrust.input_json":  redact.FieldInput,
+	"braintrust.output":      redact.FieldOutput,
+	"braintrust.output_json": redact.FieldOutput,
+	"braintrust.metadata":    redact.FieldMetadata,
+}
+
+// RedactingTracer returns a tracer whose spans redact their input, output and metadata
+// attributes with r before setting them. It returns tracer itself if r is nil.
+func RedactingTracer(tracer trace.Tracer, r *redact.Redactor) trace.Tracer {
+	if r == nil {
+		return tracer
+	}
+	return &redactingTracer{Tracer: tracer, redactor: r}
+}
+
+type redactingTracer struct {
+	trace.Tracer
+	redactor *redact.Redactor
+}
+
+func (t *redactingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	// Attributes given as options are redacted like the ones set later. The options can't be
+	// edited, so they're rebuilt from their config.
+	if cfg := trace.NewSpanStartConfig(opts...); len(cfg.Attributes()) > 0 {
+		opts = []trace.SpanStartOption{
+			trace.WithAttributes(redactAttrs(t.redactor, cfg.Attributes())...),
+			trace.WithLinks(cfg.Links()...),
+			trace.WithSpanKind(cfg.SpanKind()),
+		}
+		if !cfg.Timestamp().IsZero() {
+			opts = append(opts, trace.WithTimestamp(cfg.Timestamp()))
+		}
+		if cfg.NewRoot() {
+			opts = append(opts, trace.WithNewRoot())
+		}
+	}
+
+	ctx, span := t.Tracer.Start(ctx, name, opts...)
+	span = &redactingSpan{Span: span, redactor: t.redactor}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// redactingSpan redacts payload attributes, error messages, the status description and event
+// attributes before setting them on the wrapped span.
+type redactingSpan struct {
+	trace.Span
+	redactor *redact.Redactor
+}
+
+func (s *redactingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.Span.SetAttributes(redactAttrs(s.redactor, kv)...)
+}
+
+func (s *redactingSpan) SetStatus(code codes.Code, description string) {
+	s.Span.SetStatus(code, redactText(s.redactor, redact.FieldError, description))
+}
+
+// RecordError records err as an exception event like the OpenTelemetry SDK does, with its
+// message redacted.
+func (s *redactingSpan) RecordError(err error, opts ...trace.EventOption) {
+	if err == nil || !s.Span.IsRecording() {
+		return
+	}
+	cfg := trace.NewEventConfig(opts...)
+	attrs := []attribute.KeyValue{
+		attribute.String("exception.type", errorType(err)),
+		attribute.String("exception.message", redactText(s.redactor, redact.FieldError, err.Error())),
+	}
+	if cfg.StackTrace() {
+		attrs = append(attrs, attribute.String("exception.stacktrace", string(debug.Stack())))
+	}
+	attrs = append(attrs, redactEventAttrs(s.redactor, cfg.Attributes())...)
+	s.Span.AddEvent("exception", eventOptions(cfg, attrs)...)
+}
+
+func (s *redactingSpan) AddEvent(name string, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+	s.Span.AddEvent(name, eventOptions(cfg, redactEventAttrs(s.redactor, cfg.Attributes()))...)
+}
+
+// RedactingExporter returns an exporter that redacts the payload attributes, error messages,
+// status description and event attributes of spans with r before exporting them with
+// exporter, so every span is redacted however it was recorded. It returns exporter itself
+// if r is nil.
+func RedactingExporter(exporter sdktrace.SpanExporter, r *redact.Redactor) sdktrace.SpanExporter {
+	if r == nil {
+		return exporter
+	}
+	return &redactingExporter{SpanExporter: exporter, redactor: r}
+}
+
+type redactingExporter struct {
+	sdktrace.SpanExporter
+	redactor *redact.Redactor
+}
+
+func (e *redactingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, span := range spans {
+		redacted[i] = redactSpan(e.redactor, span)
+	}
+	return e.SpanExporter.ExportSpans(ctx, redacted)
+}
+
+// redactedSpan is a span with redacted attributes, events and status.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+	status sdktrace.Status
+}
+
+func (s *redactedSpan) Attributes() []attribute.KeyValue { return s.attrs }
+func (s *redactedSpan) Events() []sdktrace.Event         { return s.events }
+func (s *redactedSpan) Status() sdktrace.Status          { return s.status }
+
+func redactSpan(r *redact.Redactor, span sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	events := make([]sdktrace.Event, len(span.Events()))
+	for i, event := range span.Events() {
+		events[i] = event
+		events[i].Attributes = redactEventAttrs(r, event.Attributes)
+		if event.Name != "exception" {
+			continue
+		}
+		// recorded errors are redacted as errors, like by a redacting tracer
+		for j, a := range event.Attributes {
+			if a.Key == "exception.message" {
+				events[i].Attributes[j] = attribute.String(string(a.Key), redactText(r, redact.FieldError, a.Value.AsString()))
+			}
+		}
+	}
+	status := span.Status()
+	status.Description = redactText(r, redact.FieldError, status.Description)
+	return &redactedSpan{
+		ReadOnlySpan: span,
+		attrs:        redactAttrs(r, span.Attributes()),
+		events:       events,
+		status:       status,
+	}
+}
+
+// redactAttrs returns kv with the payload attributes redacted.
+func redactAttrs(r *redact.Redactor, kv []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, len(kv))
+	for i, a := range kv {
+		redacted[i] = a
+		if field, ok := redactedAttrs[a.Key]; ok && a.Value.Type() == attribute.STRING {
+			redacted[i] = attribute.String(string(a.Key), redactPayload(r, field, a.Value.AsString()))
+		}
+	}
+	return redacted
+}
+
+// redactEventAttrs returns kv with its string attributes redacted.
+func redactEventAttrs(r *redact.Redactor, kv []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, len(kv))
+	for i, a := range kv {
+		redacted[i] = a
+		if a.Value.Type() == attribute.STRING {
+			redacted[i] = attribute.String(string(a.Key), redactText(r, redact.FieldEvent, a.Value.AsString()))
+		}
+	}
+	return redacted
+}
+
+// eventOptions returns the options of an event with cfg's timestamp and the given attributes.
+func eventOptions(cfg trace.EventConfig, attrs []attribute.KeyValue) []trace.EventOption {
+	opts := []trace.EventOption{trace.WithAttributes(attrs...)}
+	if !cfg.Timestamp().IsZero() {
+		opts = append(opts, trace.WithTimestamp(cfg.Timestamp()))
+	}
+	return opts
+}
+
+// errorType returns the type of err as the OpenTelemetry SDK records it.
+func errorType(err error) string {
+	t := reflect.TypeOf(err)
+	if t.PkgPath() == "" && t.Name() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// redactText redacts a text that isn't JSON, like an error message. Text that a masker
+// replaces with something other than a string is dropped.
+func redactText(r *redact.Redactor, field, text string) string {
+	masked, _ := r.Redact(field, text).(string)
+	return masked
+}
+
+// redactPayload redacts a JSON encoded payload. Payloads that aren't JSON are redacted as text.
+func redactPayload(r *redact.Redactor, field, payload string) string {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return redactText(r, field, payload)
+	}
+	out, err := json.Marshal(r.Redact(field, v))
+	if err != nil {
+		// never record a payload that may not have been redacted
+		return "null"
+	}
+	return string(out)
+}
+
+// unwrapSpan returns the span wrapped by a redacting span, or span itself.
+func unwrapSpan(span trace.Span) trace.Span {
+	if s, ok := span.(*redactingSpan); ok {
+		return s.Span
+	}
+	return span
+}
@@ -2,18 +2,26 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/braintrustdata/braintrust-sdk-go/internal/oteltest"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/pricing"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 func TestBufferedReader(t *testing.T) {
@@ -157,7 +165,7 @@ func TestMiddlewareWithUnsupportedOpenAIEndpoint(t *testing.T) {
 	// Create middleware
 	middleware := Middleware(router, nil) //nolint:bodyclose // false positive - responses are properly closed in tests
 
-	// Test request to real OpenAI embeddings endpoint (not supported by traceopenai)
+	// Test request to an endpoint this router doesn't support
 	req := httptest.NewRequest("POST", "/v1/embeddings", strings.NewReader(`{
 		"model": "text-embedding-3-small",
 		"input": "The quick brown fox jumps over the lazy dog"
@@ -262,3 +270,248 @@ func TestToInt64(t *testing.T) {
 		})
 	}
 }
+
+// bodylessMockTracer is a mockTracer that traces requests without a body.
+type bodylessMockTracer struct {
+	mockTracer
+}
+
+func (m *bodylessMockTracer) TracesBodylessRequests() bool { return true }
+
+func TestMiddlewareWithBodylessTracer(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+
+	tracer := &bodylessMockTracer{mockTracer{tp: tp}}
+	middleware := Middleware(func(path string) MiddlewareTracer { return tracer }, nil) //nolint:bodyclose // false positive - responses are properly closed in tests
+
+	req := httptest.NewRequest("GET", "/v1/results", nil)
+	req.Body = nil
+	next := func(req *http.Request) (*http.Response, error) {
+		assert.Nil(t, req.Body, "the request body should stay nil")
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	}
+
+	resp, err := middleware(req, next)
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.True(t, tracer.startSpanCalled)
+	assert.True(t, tracer.tagSpanCalled)
+	span := exporter.FlushOne()
+	span.AssertNameIs("mock-span")
+}
+
+// usageMockTracer is a mockTracer that tags spans with a model and token metrics.
+type usageMockTracer struct {
+	mockTracer
+	model   string
+	metrics map[string]float64
+}
+
+func (m *usageMockTracer) TagSpan(span trace.Span, response io.Reader) error {
+	if err := SetJSONAttr(span, "braintrust.metadata", map[string]any{"model": m.model}); err != nil {
+		return err
+	}
+	return SetJSONAttr(span, "braintrust.metrics", m.metrics)
+}
+
+func TestMiddlewareRecordsCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   string
+		metrics map[string]float64
+		cost    float64
+		hasCost bool
+	}{
+		{
+			name:    "priced model",
+			model:   "gpt-4o-mini-2024-07-18",
+			metrics: map[string]float64{"prompt_tokens": 1000, "completion_tokens": 200},
+			cost:    (1000*0.15 + 200*0.6) / 1e6,
+			hasCost: true,
+		},
+		{
+			name:    "cost set by the tracer",
+			model:   "gpt-4o-mini",
+			metrics: map[string]float64{"prompt_tokens": 1000, "cost": 0},
+			cost:    0,
+			hasCost: true,
+		},
+		{
+			name:    "unknown model",
+			model:   "my-model",
+			metrics: map[string]float64{"prompt_tokens": 1000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp, exporter := oteltest.Setup(t)
+
+			tracer := &usageMockTracer{mockTracer: mockTracer{tp: tp}, model: test.model, metrics: test.metrics}
+			middleware := Middleware(func(path string) MiddlewareTracer { return tracer }, nil) //nolint:bodyclose // false positive - responses are properly closed in tests
+
+			ctx, costs := pricing.WithTracker(context.Background())
+			req := httptest.NewRequest("POST", "/v1/test", strings.NewReader("{}")).WithContext(ctx)
+			next := func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+			}
+
+			resp, err := middleware(req, next)
+			require.NoError(t, err)
+			_, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			span := exporter.FlushOne()
+			cost, ok := span.Metrics()["cost"]
+			assert.Equal(t, test.hasCost, ok)
+			assert.InDelta(t, test.cost, cost, 1e-12)
+			assert.InDelta(t, test.cost, costs.Total(), 1e-12)
+		})
+	}
+}
+
+func TestRedactingTracer(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+	tracer := tp.Tracer("test")
+
+	// without a redactor, spans are left as they are
+	assert.Equal(t, tracer, RedactingTracer(tracer, nil))
+
+	dropper, err := redact.DropPaths("metadata.user")
+	require.NoError(t, err)
+	redactor := redact.New(redact.Email, dropper)
+	ctx, span := RedactingTracer(tracer, redactor).Start(context.Background(), "redacted")
+	assert.Equal(t, span, trace.SpanFromContext(ctx))
+	require.NoError(t, SetJSONAttr(span, "braintrust.input_json", []any{map[string]any{"content": "I'm jane@example.com"}}))
+	require.NoError(t, SetJSONAttr(span, "braintrust.metadata", map[string]any{"model": "gpt-4o", "user": "jane"}))
+	require.NoError(t, SetJSONAttr(span, "braintrust.metrics", map[string]any{"prompt_tokens": 10}))
+	span.SetAttributes(attribute.String("braintrust.output", "reply to jane@example.com"), attribute.String("other", "jane@example.com"))
+	span.End()
+
+	out := exporter.FlushOne()
+	out.AssertJSONAttrEquals("braintrust.input_json", []any{map[string]any{"content": "I'm [EMAIL]"}})
+	out.AssertJSONAttrEquals("braintrust.metadata", map[string]any{"model": "gpt-4o"})
+	out.AssertJSONAttrEquals("braintrust.metrics", map[string]any{"prompt_tokens": 10.0})
+	out.AssertAttrEquals("braintrust.output", "reply to [EMAIL]")
+	out.AssertAttrEquals("other", "jane@example.com")
+}
+
+func TestRedactingTracer_ErrorsAndEvents(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+	tracer := RedactingTracer(tp.Tracer("test"), redact.New(redact.Email))
+
+	start := time.Now().Add(-time.Second)
+	_, span := tracer.Start(context.Background(), "redacted",
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("braintrust.input_json", `"from jane@example.com"`),
+			attribute.String("other", "jane@example.com"),
+		),
+	)
+	span.AddEvent("retry", trace.WithAttributes(attribute.String("reason", "rejected jane@example.com"), attribute.Int("attempt", 2)))
+	err := errors.New(`400 Bad Request: {"error": "invalid prompt: email jane@example.com"}`)
+	span.RecordError(err, trace.WithAttributes(attribute.String("body", "jane@example.com")))
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+
+	out := exporter.FlushOne()
+	assert.Equal(t, start.UnixNano(), out.Stub.StartTime.UnixNano())
+	out.AssertAttrEquals("braintrust.input_json", `"from [EMAIL]"`)
+	out.AssertAttrEquals("other", "jane@example.com")
+	assert.Equal(t, `400 Bad Request: {"error": "invalid prompt: email [EMAIL]"}`, out.Status().Description)
+
+	events := out.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "retry", events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("reason", "rejected [EMAIL]"), attribute.Int("attempt", 2)}, events[0].Attributes)
+	assert.Equal(t, "exception", events[1].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("exception.type", "*errors.errorString"),
+		attribute.String("exception.message", `400 Bad Request: {"error": "invalid prompt: email [EMAIL]"}`),
+		attribute.String("body", "[EMAIL]"),
+	}, events[1].Attributes)
+}
+
+func TestMiddlewareRedactsErrors(t *testing.T) {
+	tp, exporter := oteltest.Setup(t)
+
+	tracer := &redactingMockTracer{mockTracer: mockTracer{tp: tp}, redactor: redact.New(redact.Email)}
+	middleware := Middleware(func(path string) MiddlewareTracer { return tracer }, nil) //nolint:bodyclose // false positive - responses are properly closed in tests
+
+	// the provider echoes the prompt in its error
+	req := httptest.NewRequest("POST", "/v1/test", strings.NewReader(`{"prompt": "I'm jane@example.com"}`))
+	next := func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return nil, fmt.Errorf("upstream rejected %s", body)
+	}
+
+	_, err := middleware(req, next)
+	require.Error(t, err)
+
+	span := exporter.FlushOne()
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, `upstream rejected {"prompt": "I'm [EMAIL]"}`, span.Status().Description)
+	require.Len(t, span.Events(), 1)
+	assert.Contains(t, span.Events()[0].Attributes, attribute.String("exception.message", `upstream rejected {"prompt": "I'm [EMAIL]"}`))
+}
+
+// redactingMockTracer is a mockTracer whose spans are redacted.
+type redactingMockTracer struct {
+	mockTracer
+	redactor *redact.Redactor
+}
+
+func (m *redactingMockTracer) StartSpan(ctx context.Context, start time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span := RedactingTracer(m.tp.Tracer("braintrust"), m.redactor).Start(ctx, "mock-span", trace.WithTimestamp(start))
+	_, err := io.ReadAll(request)
+	return ctx, span, err
+}
+
+// decodingMockTracer is a mockTracer that decodes the request body like the real tracers,
+// which stops reading at the end of the JSON value.
+type decodingMockTracer struct {
+	mockTracer
+}
+
+func (m *decodingMockTracer) StartSpan(ctx context.Context, start time.Time, request io.Reader) (context.Context, trace.Span, error) {
+	ctx, span, _ := m.mockTracer.StartSpan(ctx, start, request)
+	var raw map[string]any
+	return ctx, span, json.NewDecoder(request).Decode(&raw)
+}
+
+func TestMiddlewareSendsWholeBody(t *testing.T) {
+	tp, _ := oteltest.Setup(t)
+
+	tests := []struct {
+		name   string
+		tracer MiddlewareTracer
+		body   string
+	}{
+		{"tracer doesn't read the body", &mockTracer{tp: tp}, "{\"model\": \"test\"}\n"},
+		{"tracer stops at the end of the JSON value", &decodingMockTracer{mockTracer{tp: tp}}, "{\"model\": \"test\"}\n"},
+		{"tracer fails to read the body", &decodingMockTracer{mockTracer{tp: tp}}, "{\"model\": tes"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			middleware := Middleware(func(path string) MiddlewareTracer { return test.tracer }, nil) //nolint:bodyclose // false positive - responses are properly closed in tests
+
+			// the body arrives in small reads, like a streamed request body
+			req := httptest.NewRequest("POST", "/v1/test", iotest.OneByteReader(strings.NewReader(test.body)))
+			next := func(req *http.Request) (*http.Response, error) {
+				sent, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, test.body, string(sent))
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+			}
+
+			resp, err := middleware(req, next)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		})
+	}
+}
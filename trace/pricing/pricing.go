@@ -0,0 +1,164 @@
+// Package pricing estimates the cost of LLM calls from their token usage.
+//
+// The contrib middlewares use it to add a "cost" metric, in US dollars, to the spans of
+// LLM calls whose model has a price. Prices for common OpenAI, Anthropic and Gemini models
+// are built in, and can be overridden or extended with [Set]:
+//
+//	pricing.Set("my-fine-tuned-model", pricing.Price{Input: 3, Output: 12})
+//
+// Model names match a price if they are equal to its name, or start with its name followed
+// by a "-", so "gpt-4o-2024-08-06" uses the price of "gpt-4o". The longest matching name wins.
+package pricing
+
+import (
+	"strings"
+	"sync"
+)
+
+// Price is the price of a model in US dollars per million tokens.
+type Price struct {
+	// Input is the price of prompt tokens that were not read from or written to a cache.
+	Input float64
+
+	// Output is the price of completion tokens.
+	Output float64
+
+	// CachedInput is the price of prompt tokens read from a cache. (default: Input)
+	CachedInput float64
+
+	// CacheWrite is the price of prompt tokens written to a cache. (default: Input)
+	CacheWrite float64
+
+	// Reasoning is the price of reasoning (or thinking) tokens. (default: Output)
+	Reasoning float64
+}
+
+// Cost returns the cost in US dollars of a call with the given token metrics, as recorded
+// in the "braintrust.metrics" attribute of LLM spans:
+//
+//   - prompt_tokens: all prompt tokens, including cached ones
+//   - prompt_cached_tokens: prompt tokens read from a cache
+//   - prompt_cache_creation_tokens: prompt tokens written to a cache
+//   - completion_tokens: completion tokens, including OpenAI reasoning tokens
+//   - completion_reasoning_tokens: reasoning tokens included in completion_tokens
+//   - thoughts_token_count: Gemini thinking tokens, which are not included in completion_tokens
+func (p Price) Cost(metrics map[string]float64) float64 {
+	cached := metrics["prompt_cached_tokens"]
+	written := metrics["prompt_cache_creation_tokens"]
+	input := max(metrics["prompt_tokens"]-cached-written, 0)
+	reasoning := metrics["completion_reasoning_tokens"]
+	output := max(metrics["completion_tokens"]-reasoning, 0)
+	reasoning += metrics["thoughts_token_count"]
+
+	cost := input*p.Input +
+		cached*orDefault(p.CachedInput, p.Input) +
+		written*orDefault(p.CacheWrite, p.Input) +
+		output*p.Output +
+		reasoning*orDefault(p.Reasoning, p.Output)
+	return cost / 1e6
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+var (
+	mu     sync.RWMutex
+	prices = defaultPrices()
+)
+
+// Set sets the price of model, overriding the built-in price if there is one.
+// It is safe to call concurrently with traced calls.
+func Set(model string, p Price) {
+	mu.Lock()
+	defer mu.Unlock()
+	prices[model] = p
+}
+
+// Lookup returns the price of model, and false if no price matches it.
+func Lookup(model string) (Price, bool) {
+	// Gemini model names may be resource names
+	model = strings.TrimPrefix(model, "models/")
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if p, ok := prices[model]; ok {
+		return p, true
+	}
+	var match string
+	var price Price
+	for name, p := range prices {
+		if len(name) > len(match) && strings.HasPrefix(model, name+"-") {
+			match, price = name, p
+		}
+	}
+	return price, match != ""
+}
+
+// Cost returns the cost in US dollars of a call to model with the given token metrics, and
+// false if the model has no price or the metrics have no token counts. See [Price.Cost].
+func Cost(model string, metrics map[string]float64) (float64, bool) {
+	_, hasPrompt := metrics["prompt_tokens"]
+	_, hasCompletion := metrics["completion_tokens"]
+	if model == "" || (!hasPrompt && !hasCompletion) {
+		return 0, false
+	}
+	p, ok := Lookup(model)
+	if !ok {
+		return 0, false
+	}
+	return p.Cost(metrics), true
+}
+
+// defaultPrices returns the built-in prices, from the providers' published pricing.
+func defaultPrices() map[string]Price {
+	return map[string]Price{
+		// OpenAI
+		"gpt-5":                  {Input: 1.25, CachedInput: 0.125, Output: 10},
+		"gpt-5-mini":             {Input: 0.25, CachedInput: 0.025, Output: 2},
+		"gpt-5-nano":             {Input: 0.05, CachedInput: 0.005, Output: 0.4},
+		"gpt-4.1":                {Input: 2, CachedInput: 0.5, Output: 8},
+		"gpt-4.1-mini":           {Input: 0.4, CachedInput: 0.1, Output: 1.6},
+		"gpt-4.1-nano":           {Input: 0.1, CachedInput: 0.025, Output: 0.4},
+		"gpt-4o":                 {Input: 2.5, CachedInput: 1.25, Output: 10},
+		"gpt-4o-mini":            {Input: 0.15, CachedInput: 0.075, Output: 0.6},
+		"gpt-4-turbo":            {Input: 10, Output: 30},
+		"gpt-4":                  {Input: 30, Output: 60},
+		"gpt-3.5-turbo":          {Input: 0.5, Output: 1.5},
+		"o1":                     {Input: 15, CachedInput: 7.5, Output: 60},
+		"o1-mini":                {Input: 1.1, CachedInput: 0.55, Output: 4.4},
+		"o3":                     {Input: 2, CachedInput: 0.5, Output: 8},
+		"o3-mini":                {Input: 1.1, CachedInput: 0.55, Output: 4.4},
+		"o4-mini":                {Input: 1.1, CachedInput: 0.275, Output: 4.4},
+		"gpt-image-1":            {Input: 5, CachedInput: 1.25, Output: 40},
+		"text-embedding-3-small": {Input: 0.02},
+		"text-embedding-3-large": {Input: 0.13},
+		"text-embedding-ada-002": {Input: 0.1},
+
+		// Anthropic
+		"claude-opus-4":     {Input: 15, CachedInput: 1.5, CacheWrite: 18.75, Output: 75},
+		"claude-opus-4-1":   {Input: 15, CachedInput: 1.5, CacheWrite: 18.75, Output: 75},
+		"claude-opus-4-5":   {Input: 5, CachedInput: 0.5, CacheWrite: 6.25, Output: 25},
+		"claude-sonnet-4":   {Input: 3, CachedInput: 0.3, CacheWrite: 3.75, Output: 15},
+		"claude-sonnet-4-5": {Input: 3, CachedInput: 0.3, CacheWrite: 3.75, Output: 15},
+		"claude-haiku-4-5":  {Input: 1, CachedInput: 0.1, CacheWrite: 1.25, Output: 5},
+		"claude-3-7-sonnet": {Input: 3, CachedInput: 0.3, CacheWrite: 3.75, Output: 15},
+		"claude-3-5-sonnet": {Input: 3, CachedInput: 0.3, CacheWrite: 3.75, Output: 15},
+		"claude-3-5-haiku":  {Input: 0.8, CachedInput: 0.08, CacheWrite: 1, Output: 4},
+		"claude-3-opus":     {Input: 15, CachedInput: 1.5, CacheWrite: 18.75, Output: 75},
+		"claude-3-haiku":    {Input: 0.25, CachedInput: 0.03, CacheWrite: 0.3, Output: 1.25},
+
+		// Gemini
+		"gemini-2.5-pro":        {Input: 1.25, CachedInput: 0.31, Output: 10},
+		"gemini-2.5-flash":      {Input: 0.3, CachedInput: 0.075, Output: 2.5},
+		"gemini-2.5-flash-lite": {Input: 0.1, CachedInput: 0.025, Output: 0.4},
+		"gemini-2.0-flash":      {Input: 0.1, CachedInput: 0.025, Output: 0.4},
+		"gemini-2.0-flash-lite": {Input: 0.075, Output: 0.3},
+		"gemini-1.5-pro":        {Input: 1.25, Output: 5},
+		"gemini-1.5-flash":      {Input: 0.075, Output: 0.3},
+	}
+}
@@ -0,0 +1,136 @@
+package pricing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	p, ok := Lookup("gpt-4o")
+	require.True(t, ok)
+	assert.Equal(t, 2.5, p.Input)
+
+	// dated versions use the price of their model, and the longest name wins
+	p, ok = Lookup("gpt-4o-mini-2024-07-18")
+	require.True(t, ok)
+	assert.Equal(t, 0.15, p.Input)
+
+	p, ok = Lookup("claude-opus-4-1-20250805")
+	require.True(t, ok)
+	assert.Equal(t, 75.0, p.Output)
+
+	p, ok = Lookup("models/gemini-2.0-flash-001")
+	require.True(t, ok)
+	assert.Equal(t, 0.4, p.Output)
+
+	// names only match up to a "-"
+	_, ok = Lookup("gpt-4.5-preview")
+	assert.False(t, ok)
+	_, ok = Lookup("unknown-model")
+	assert.False(t, ok)
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	_, ok := Lookup("test-set-model-v2")
+	require.False(t, ok)
+
+	Set("test-set-model", Price{Input: 1, Output: 2})
+	p, ok := Lookup("test-set-model-v2")
+	require.True(t, ok)
+	assert.Equal(t, Price{Input: 1, Output: 2}, p)
+
+	Set("test-set-model", Price{Input: 3, Output: 4})
+	p, _ = Lookup("test-set-model")
+	assert.Equal(t, 3.0, p.Input)
+}
+
+func TestPrice_Cost(t *testing.T) {
+	t.Parallel()
+
+	p := Price{Input: 2, Output: 8, CachedInput: 0.5, CacheWrite: 2.5, Reasoning: 10}
+
+	// plain prompt and completion
+	assert.InDelta(t, (1000*2+500*8)/1e6, p.Cost(map[string]float64{
+		"prompt_tokens":     1000,
+		"completion_tokens": 500,
+	}), 1e-12)
+
+	// cached and cache write tokens are part of prompt_tokens, reasoning tokens of completion_tokens
+	assert.InDelta(t, (400*2+500*0.5+100*2.5+300*8+200*10)/1e6, p.Cost(map[string]float64{
+		"prompt_tokens":                1000,
+		"prompt_cached_tokens":         500,
+		"prompt_cache_creation_tokens": 100,
+		"completion_tokens":            500,
+		"completion_reasoning_tokens":  200,
+	}), 1e-12)
+
+	// Gemini thinking tokens are in addition to completion_tokens
+	assert.InDelta(t, (1000*2+500*8+200*10)/1e6, p.Cost(map[string]float64{
+		"prompt_tokens":        1000,
+		"completion_tokens":    500,
+		"thoughts_token_count": 200,
+	}), 1e-12)
+
+	// rates default to the input and output prices
+	plain := Price{Input: 2, Output: 8}
+	assert.InDelta(t, (1000*2+500*8)/1e6, plain.Cost(map[string]float64{
+		"prompt_tokens":               1000,
+		"prompt_cached_tokens":        400,
+		"completion_tokens":           500,
+		"completion_reasoning_tokens": 200,
+	}), 1e-12)
+}
+
+func TestCost(t *testing.T) {
+	t.Parallel()
+
+	cost, ok := Cost("gpt-4o-mini", map[string]float64{"prompt_tokens": 1000, "completion_tokens": 1000})
+	require.True(t, ok)
+	assert.InDelta(t, (1000*0.15+1000*0.6)/1e6, cost, 1e-12)
+
+	_, ok = Cost("unknown-model", map[string]float64{"prompt_tokens": 1000})
+	assert.False(t, ok)
+	_, ok = Cost("", map[string]float64{"prompt_tokens": 1000})
+	assert.False(t, ok)
+	_, ok = Cost("gpt-4o", map[string]float64{"time_to_first_token": 0.5})
+	assert.False(t, ok, "no token counts")
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+
+	// tracking without a tracker does nothing
+	Track(context.Background(), 1)
+
+	ctx, outer := WithTracker(context.Background())
+	Track(ctx, 0.5)
+
+	var wg sync.WaitGroup
+	inners := make([]*Tracker, 4)
+	for i := range inners {
+		innerCtx, inner := WithTracker(ctx)
+		inners[i] = inner
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Track(innerCtx, 0.25)
+			Track(innerCtx, 0.25)
+		}()
+	}
+	wg.Wait()
+
+	for _, inner := range inners {
+		assert.Equal(t, 0.5, inner.Total())
+		assert.Equal(t, 2, inner.Calls())
+	}
+	assert.Equal(t, 2.5, outer.Total())
+	assert.Equal(t, 9, outer.Calls())
+}
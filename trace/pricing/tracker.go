@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"context"
+	"sync"
+)
+
+// trackerKey is the context key for the innermost Tracker.
+type trackerKey struct{}
+
+// Tracker totals the cost of the LLM calls made with a context returned by [WithTracker].
+// It is safe for concurrent use.
+type Tracker struct {
+	parent *Tracker
+
+	mu    sync.Mutex
+	total float64
+	calls int
+}
+
+// WithTracker returns a context carrying a new Tracker. Costs tracked with the returned
+// context are also added to the trackers of ctx, if any.
+func WithTracker(ctx context.Context) (context.Context, *Tracker) {
+	parent, _ := ctx.Value(trackerKey{}).(*Tracker)
+	t := &Tracker{parent: parent}
+	return context.WithValue(ctx, trackerKey{}, t), t
+}
+
+// Track adds the cost of a call in US dollars to the trackers of ctx.
+// It does nothing if ctx has no tracker.
+func Track(ctx context.Context, cost float64) {
+	t, _ := ctx.Value(trackerKey{}).(*Tracker)
+	for ; t != nil; t = t.parent {
+		t.mu.Lock()
+		t.total += cost
+		t.calls++
+		t.mu.Unlock()
+	}
+}
+
+// Total returns the total cost in US dollars of the calls tracked so far.
+func (t *Tracker) Total() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Calls returns the number of calls with a known cost tracked so far.
+func (t *Tracker) Calls() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls
+}
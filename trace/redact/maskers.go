@@ -0,0 +1,103 @@
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Strings returns a [Masker] that replaces every string value in the payload with mask(s).
+// Numbers are masked as their decimal text, and replaced with the masked string if mask
+// changes it. Map keys are left as they are.
+func Strings(mask func(s string) string) Masker {
+	return MaskerFunc(func(field string, payload any) any {
+		return mapStrings(payload, mask)
+	})
+}
+
+func mapStrings(v any, mask func(string) string) any {
+	switch v := v.(type) {
+	case string:
+		return mask(v)
+	case json.Number:
+		if masked := mask(v.String()); masked != v.String() {
+			return masked
+		}
+		return v
+	case map[string]any:
+		for k, e := range v {
+			v[k] = mapStrings(e, mask)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = mapStrings(e, mask)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// Regex returns a [Masker] that replaces matches of re in every string of the payload with
+// replacement, which may refer to submatches like [regexp.Regexp.ReplaceAllString].
+func Regex(re *regexp.Regexp, replacement string) Masker {
+	return Strings(func(s string) string {
+		return re.ReplaceAllString(s, replacement)
+	})
+}
+
+var (
+	emailRe      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phoneRe      = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{3}\)\s?|\b\d{3}[\s.-])\d{3}[\s.-]\d{4}\b|\+\d{10,14}\b`)
+	creditCardRe = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+// Email masks email addresses as "[EMAIL]".
+var Email = Regex(emailRe, "[EMAIL]")
+
+// Phone masks phone numbers of 10 digits, with an optional country code, as "[PHONE]". The
+// groups of digits must be separated by spaces, dots or dashes, or the area code must be in
+// parentheses, so that IDs and timestamps aren't masked. Unseparated numbers are only masked
+// in the international "+" form.
+var Phone = Regex(phoneRe, "[PHONE]")
+
+// CreditCard masks credit card numbers as "[CREDIT_CARD]". Runs of 13 to 19 digits,
+// optionally separated by spaces or dashes, are only masked if their check digit is valid.
+var CreditCard = Strings(func(s string) string {
+	return creditCardRe.ReplaceAllStringFunc(s, func(match string) string {
+		if !luhnValid(match) {
+			return match
+		}
+		return "[CREDIT_CARD]"
+	})
+})
+
+// PII returns the built-in detectors: [Email], [CreditCard] and [Phone], in an order where
+// they don't mask parts of each other's matches.
+func PII() []Masker {
+	return []Masker{Email, CreditCard, Phone}
+}
+
+// luhnValid returns true if the digits of s have a valid Luhn check digit.
+func luhnValid(s string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d, err := strconv.Atoi(digits[i : i+1])
+		if err != nil {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a step of a path: a map key, an array index, or a wildcard for either.
+type segment struct {
+	key   string
+	index int // -1 for a map key
+	any   bool
+}
+
+// DropPaths returns a [Masker] that removes the values at paths from payloads. A path starts
+// with the field it applies to ("input", "output", "metadata", or "*" for all of them),
+// followed by map keys separated by dots and array indexes in brackets. A "*" key or a "[*]"
+// index matches every key or element:
+//
+//	dropper, err := redact.DropPaths(
+//		"input[*].content",                  // the content of every input message
+//		"output.choices[0].message.content", // the content of the first choice
+//		"metadata.user",                     // the user field of the metadata
+//		"*.customer",                        // the customer field of every field
+//	)
+//
+// Dropping a whole field records it as null. DropPaths returns an error if a path is invalid.
+func DropPaths(paths ...string) (Masker, error) {
+	parsed := make([][]segment, len(paths))
+	for i, p := range paths {
+		segs, err := parsePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("redact: invalid path %q: %w", p, err)
+		}
+		parsed[i] = segs
+	}
+
+	return MaskerFunc(func(field string, payload any) any {
+		for _, segs := range parsed {
+			if segs[0].key != field && !segs[0].any {
+				continue
+			}
+			if len(segs) == 1 {
+				return nil
+			}
+			payload = drop(payload, segs[1:])
+		}
+		return payload
+	}), nil
+}
+
+// parsePath parses a path like "input.messages[*].content" into its segments.
+func parsePath(path string) ([]segment, error) {
+	var segs []segment
+	for _, part := range strings.Split(path, ".") {
+		name, rest, hasIndex := strings.Cut(part, "[")
+		if name == "" && (len(segs) == 0 || !hasIndex) {
+			return nil, fmt.Errorf("empty key")
+		}
+		if hasIndex && rest == "" {
+			return nil, fmt.Errorf("missing ]")
+		}
+		if name != "" {
+			segs = append(segs, segment{key: name, index: -1, any: name == "*"})
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("missing ]")
+			}
+			if idx == "*" {
+				segs = append(segs, segment{index: 0, any: true})
+			} else {
+				i, err := strconv.Atoi(idx)
+				if err != nil || i < 0 {
+					return nil, fmt.Errorf("invalid index %q", idx)
+				}
+				segs = append(segs, segment{index: i})
+			}
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("unexpected %q after ]", after)
+			}
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	if segs[0].index >= 0 {
+		return nil, fmt.Errorf("a path must start with a field")
+	}
+	return segs, nil
+}
+
+// drop removes the values at segs from v, and returns v.
+func drop(v any, segs []segment) any {
+	seg, last := segs[0], len(segs) == 1
+	switch v := v.(type) {
+	case map[string]any:
+		if seg.index >= 0 {
+			return v
+		}
+		for k, e := range v {
+			if !seg.any && k != seg.key {
+				continue
+			}
+			if last {
+				delete(v, k)
+			} else {
+				v[k] = drop(e, segs[1:])
+			}
+		}
+		return v
+	case []any:
+		if seg.index < 0 {
+			return v
+		}
+		if seg.any {
+			if last {
+				return []any{}
+			}
+			for i, e := range v {
+				v[i] = drop(e, segs[1:])
+			}
+			return v
+		}
+		if seg.index >= len(v) {
+			return v
+		}
+		if last {
+			return append(v[:seg.index:seg.index], v[seg.index+1:]...)
+		}
+		v[seg.index] = drop(v[seg.index], segs[1:])
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,70 @@
+// Package redact masks sensitive data in the payloads of traced LLM calls before they are
+// recorded on spans.
+//
+// A [Redactor] runs a pipeline of [Masker]s over the input, output and metadata of spans. Set
+// with braintrust.WithRedactor, it redacts every span the client exports to Braintrust. Set
+// with a contrib integration's WithRedactor option, it redacts the integration's spans when
+// they are recorded, whichever exporter they go to:
+//
+//	dropper, err := redact.DropPaths("input[*].name", "metadata.user")
+//	if err != nil {
+//		return err
+//	}
+//	redactor := redact.New(
+//		redact.Email, redact.CreditCard, redact.Phone,
+//		redact.Regex(regexp.MustCompile(`ACCT-\d{8}`), "[ACCOUNT]"),
+//		dropper,
+//	)
+//	client, err := braintrust.New(tp, braintrust.WithRedactor(redactor))
+package redact
+
+// The fields of a span that are redacted.
+const (
+	FieldInput    = "input"
+	FieldOutput   = "output"
+	FieldMetadata = "metadata"
+
+	// FieldError is the message of recorded errors and the span's status description,
+	// which may echo the payload of a failed call. Its payload is always a string.
+	FieldError = "error"
+
+	// FieldEvent is the value of the string attributes of span events.
+	FieldEvent = "event"
+)
+
+// Masker masks sensitive data in a payload. The payload is a JSON value decoded into
+// map[string]any, []any, string, json.Number, bool or nil, and field is the span field it
+// is recorded in, such as [FieldInput] or [FieldError]. Mask returns the masked payload, and
+// may modify the given one in place.
+type Masker interface {
+	Mask(field string, payload any) any
+}
+
+// MaskerFunc is a custom [Masker].
+type MaskerFunc func(field string, payload any) any
+
+// Mask calls f(field, payload).
+func (f MaskerFunc) Mask(field string, payload any) any {
+	return f(field, payload)
+}
+
+// Redactor runs a pipeline of maskers over traced payloads. It is safe for concurrent use.
+type Redactor struct {
+	maskers []Masker
+}
+
+// New creates a Redactor that runs maskers in order.
+func New(maskers ...Masker) *Redactor {
+	return &Redactor{maskers: maskers}
+}
+
+// Redact returns payload with every masker applied. It returns payload unchanged if r is nil.
+func (r *Redactor) Redact(field string, payload any) any {
+	if r == nil {
+		return payload
+	}
+	for _, m := range r.maskers {
+		payload = m.Mask(field, payload)
+	}
+	return payload
+}
@@ -0,0 +1,175 @@
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decode decodes a JSON payload like the tracers do.
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	require.NoError(t, dec.Decode(&v))
+	return v
+}
+
+func TestBuiltInDetectors(t *testing.T) {
+	t.Parallel()
+
+	r := New(PII()...)
+	tests := []struct {
+		in, out string
+	}{
+		{"write to jane.doe+work@example.co.uk today", "write to [EMAIL] today"},
+		{"call (415) 555-0100 or +44 020 555 0100", "call [PHONE] or [PHONE]"},
+		{"call 415.555.0100", "call [PHONE]"},
+		{"call (415)555-0100 or +14155550100", "call [PHONE] or [PHONE]"},
+		{"visa 4111-1111-1111-1111 exp 12/30", "visa [CREDIT_CARD] exp 12/30"},
+		{"amex 378282246310005", "amex [CREDIT_CARD]"},
+		// digit runs with an invalid check digit aren't cards
+		{"order 4111111111111112", "order 4111111111111112"},
+		{"nothing to see here", "nothing to see here"},
+		// digit runs without separators aren't phone numbers
+		{"id 4155550100", "id 4155550100"},
+		{"created at 1718049600123", "created at 1718049600123"},
+		{"trace 0af7651916cd43dd8448eb211c80319c", "trace 0af7651916cd43dd8448eb211c80319c"},
+		{"on 2024-06-10 at 12:30:45", "on 2024-06-10 at 12:30:45"},
+		{"order 12345678901234567890", "order 12345678901234567890"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.out, r.Redact(FieldInput, test.in), test.in)
+	}
+}
+
+func TestBuiltInDetectors_Numbers(t *testing.T) {
+	t.Parallel()
+
+	r := New(PII()...)
+	payload := decode(t, `{"card": 4111111111111111, "account": 4111111111111112, "amount": 12.5, "created": 1718049600123}`)
+	assert.Equal(t, map[string]any{
+		"card":    "[CREDIT_CARD]",
+		"account": json.Number("4111111111111112"),
+		"amount":  json.Number("12.5"),
+		"created": json.Number("1718049600123"),
+	}, r.Redact(FieldInput, payload))
+}
+
+func TestRegexAndStrings(t *testing.T) {
+	t.Parallel()
+
+	r := New(
+		Regex(regexp.MustCompile(`ACCT-(\d{4})\d{4}`), "ACCT-${1}****"),
+		Strings(strings.ToUpper),
+	)
+	payload := decode(t, `{"messages": [{"role": "user", "content": "balance of ACCT-12345678?"}], "n": 1}`)
+	assert.Equal(t, map[string]any{
+		"messages": []any{map[string]any{"role": "USER", "content": "BALANCE OF ACCT-1234****?"}},
+		"n":        json.Number("1"),
+	}, r.Redact(FieldInput, payload))
+}
+
+func TestDropPaths(t *testing.T) {
+	t.Parallel()
+
+	payload := func() any {
+		return decode(t, `{
+			"messages": [
+				{"role": "system", "content": "secret", "name": "a"},
+				{"role": "user", "content": "hello", "name": "b"}
+			],
+			"user": {"email": "x@example.com", "id": 1}
+		}`)
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		field string
+		want  string
+	}{
+		{
+			name:  "wildcard index",
+			paths: []string{"input.messages[*].name"},
+			field: FieldInput,
+			want:  `{"messages": [{"role": "system", "content": "secret"}, {"role": "user", "content": "hello"}], "user": {"email": "x@example.com", "id": 1}}`,
+		},
+		{
+			name:  "array element",
+			paths: []string{"input.messages[0]"},
+			field: FieldInput,
+			want:  `{"messages": [{"role": "user", "content": "hello", "name": "b"}], "user": {"email": "x@example.com", "id": 1}}`,
+		},
+		{
+			name:  "wildcard field and key",
+			paths: []string{"*.user.*", "*.messages[1].content"},
+			field: FieldOutput,
+			want:  `{"messages": [{"role": "system", "content": "secret", "name": "a"}, {"role": "user", "name": "b"}], "user": {}}`,
+		},
+		{
+			name:  "other field",
+			paths: []string{"output.user"},
+			field: FieldInput,
+			want:  `{"messages": [{"role": "system", "content": "secret", "name": "a"}, {"role": "user", "content": "hello", "name": "b"}], "user": {"email": "x@example.com", "id": 1}}`,
+		},
+		{
+			name:  "missing paths",
+			paths: []string{"input.nope.deeper", "input.messages[5]", "input.user[0]"},
+			field: FieldInput,
+			want:  `{"messages": [{"role": "system", "content": "secret", "name": "a"}, {"role": "user", "content": "hello", "name": "b"}], "user": {"email": "x@example.com", "id": 1}}`,
+		},
+		{
+			name:  "whole field",
+			paths: []string{"input"},
+			field: FieldInput,
+			want:  `null`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dropper, err := DropPaths(test.paths...)
+			require.NoError(t, err)
+			got := dropper.Mask(test.field, payload())
+			assert.Equal(t, decode(t, test.want), got)
+		})
+	}
+}
+
+func TestDropPaths_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"", "[0]", "input..content", "input[", "input[x]", "input[0", "input[0]x"} {
+		_, err := DropPaths("input.ok", path)
+		assert.ErrorContains(t, err, "invalid path", path)
+	}
+	_, err := DropPaths("input[0][1].a", "*.b[*]")
+	assert.NoError(t, err)
+}
+
+func TestRedactor(t *testing.T) {
+	t.Parallel()
+
+	var nilRedactor *Redactor
+	assert.Equal(t, "jane@example.com", nilRedactor.Redact(FieldInput, "jane@example.com"))
+	assert.Equal(t, "jane@example.com", New().Redact(FieldInput, "jane@example.com"))
+
+	// maskers run in order and see the field
+	var fields []string
+	r := New(
+		MaskerFunc(func(field string, payload any) any {
+			fields = append(fields, field)
+			return payload.(string) + " first"
+		}),
+		MaskerFunc(func(field string, payload any) any {
+			return payload.(string) + " second"
+		}),
+	)
+	assert.Equal(t, "x first second", r.Redact(FieldMetadata, "x"))
+	assert.Equal(t, []string{FieldMetadata}, fields)
+}
@@ -41,6 +41,8 @@ import (
 
 	"github.com/braintrustdata/braintrust-sdk-go/internal/auth"
 	"github.com/braintrustdata/braintrust-sdk-go/logger"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/internal"
+	"github.com/braintrustdata/braintrust-sdk-go/trace/redact"
 )
 
 // Config holds configuration for Braintrust tracing
@@ -59,6 +61,9 @@ type Config struct {
 	// Test override: provide custom exporter (e.g., memory exporter for tests)
 	Exporter sdktrace.SpanExporter
 
+	// Redactor masks the payloads of spans before they are exported (optional)
+	Redactor *redact.Redactor
+
 	// Logger
 	Logger logger.Logger
 }
@@ -100,6 +105,9 @@ func GetSpanProcessor(session *auth.Session, cfg Config) (sdktrace.SpanProcessor
 		log.Debug("created OTLP HTTP exporter", "endpoint", apiInfo.APIURL)
 	}
 
+	// Redact spans before they leave the process
+	exporter = internal.RedactingExporter(exporter, cfg.Redactor)
+
 	// Wrap in batch processor
 	batchProcessor := sdktrace.NewBatchSpanProcessor(exporter)
 